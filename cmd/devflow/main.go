@@ -5,11 +5,14 @@ import (
 	"os"
 
 	"github.com/Ilia01/devflow/internal/app"
+	"github.com/Ilia01/devflow/internal/utils"
 )
 
 func main() {
 	if err := app.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "\n%s\n", err)
+		fmt.Fprintln(os.Stderr)
+		utils.FprintColored(os.Stderr, err.Error(), utils.ColorRed)
+		fmt.Fprintln(os.Stderr)
 		os.Exit(1)
 	}
 }
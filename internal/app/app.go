@@ -2,10 +2,18 @@ package app
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/Ilia01/devflow/internal/config"
+	"github.com/Ilia01/devflow/internal/logging"
+	"github.com/Ilia01/devflow/internal/prompt"
+	"github.com/Ilia01/devflow/internal/theme"
+	"github.com/Ilia01/devflow/internal/utils"
 )
 
 var (
@@ -15,36 +23,132 @@ var (
 		Long:          "DevFlow helps you manage Jira tickets and Git workflows from the terminal.",
 		SilenceUsage:  true,
 		SilenceErrors: true,
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if verbose {
 				os.Setenv("DEVFLOW_DEBUG", "1")
+				logging.SetLevel(logging.LevelDebug)
+			}
+			prompt.NoConfirm = noConfirm
+			if err := applyColorMode(colorFlag); err != nil {
+				return err
 			}
+			if err := applyLogFormat(logFormatFlag); err != nil {
+				return err
+			}
+			return applyTheme(noThemeFlag)
 		},
 	}
 
-	verbose bool
-
-	initHandler       = handleInit
-	startHandler      = handleStart
-	statusHandler     = handleStatus
-	listHandler       = handleList
-	searchHandler     = handleSearch
-	openHandler       = handleOpen
-	commitHandler     = handleCommit
-	doneHandler       = handleDone
-	configShowHandler = handleConfigShow
-	configSetHandler  = handleConfigSet
-	configValHandler  = handleConfigValidate
-	configPathHandler = handleConfigPath
-	testJiraHandler   = handleTestJira
+	verbose       bool
+	profileFlag   string
+	noConfirm     bool
+	colorFlag     string
+	logFormatFlag string
+	noThemeFlag   bool
+
+	initHandler               = handleInit
+	startHandler              = handleStart
+	statusHandler             = handleStatus
+	listHandler               = handleList
+	searchHandler             = handleSearch
+	openHandler               = handleOpen
+	commitHandler             = handleCommit
+	doneHandler               = handleDone
+	configShowHandler         = handleConfigShow
+	configSetHandler          = handleConfigSet
+	configValHandler          = handleConfigValidate
+	configPathHandler         = handleConfigPath
+	testJiraHandler           = handleTestJira
+	profileListHandler        = handleProfileList
+	profileAddHandler         = handleProfileAdd
+	profileUseHandler         = handleProfileUse
+	profileRemoveHandler      = handleProfileRemove
+	commentHandler            = handleComment
+	logHandler                = handleLog
+	syncHandler               = handleSync
+	linkHandler               = handleLink
+	subtaskHandler            = handleSubtask
+	parentHandler             = handleParent
+	authLoginHandler          = handleAuthLogin
+	authListHandler           = handleAuthList
+	authRemoveHandler         = handleAuthRemove
+	authJiraOAuthSetupHandler = handleAuthJiraOAuthSetup
 )
 
 func Execute() error {
 	return rootCmd.Execute()
 }
 
+// applyColorMode translates the --color flag into a utils.ColorMode. The
+// flag's default "auto" (or an empty value) leaves auto-detection in place.
+func applyColorMode(value string) error {
+	switch value {
+	case "", "auto":
+		utils.SetColorMode(utils.ColorAuto)
+	case "always":
+		utils.SetColorMode(utils.ColorAlways)
+	case "never":
+		utils.SetColorMode(utils.ColorNever)
+	default:
+		return fmt.Errorf("--color must be one of: auto, always, never")
+	}
+	return nil
+}
+
+// applyLogFormat translates the --log-format flag into a logging.Formatter
+// for devflow's background/diagnostic logger (sync progress, pipeline
+// polling), switching between pretty colored output for interactive use
+// and plain/JSON output for CI.
+func applyLogFormat(value string) error {
+	switch value {
+	case "", "pretty":
+		logging.SetFormatter(logging.PrettyFormatter{})
+	case "plain":
+		logging.SetFormatter(logging.PlainFormatter{})
+	case "json":
+		logging.SetFormatter(logging.JSONFormatter{})
+	default:
+		return fmt.Errorf("--log-format must be one of: pretty, plain, json")
+	}
+	return nil
+}
+
+// applyTheme sets the process-wide theme.Active theme: --no-theme disables
+// it entirely, otherwise it's the user's configured base theme (dark,
+// unless config.toml sets preferences.theme = "light") with
+// ~/.devflow/theme.toml overlaid on top, if present.
+func applyTheme(disabled bool) error {
+	if disabled {
+		theme.SetActive(theme.Disabled())
+		return nil
+	}
+
+	base := theme.Dark()
+	if settings, err := config.Load(); err == nil && settings.Preferences.Theme == "light" {
+		base = theme.Light()
+	}
+
+	dir, err := config.ConfigDir()
+	if err != nil {
+		theme.SetActive(base)
+		return nil
+	}
+
+	loaded, err := theme.Load(dir, base)
+	if err != nil {
+		return fmt.Errorf("load theme: %w", err)
+	}
+	theme.SetActive(loaded)
+	return nil
+}
+
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Use this profile for a single invocation")
+	rootCmd.PersistentFlags().BoolVar(&noConfirm, "no-confirm", false, "Auto-accept defaults instead of prompting, for scripted use")
+	rootCmd.PersistentFlags().StringVar(&colorFlag, "color", "auto", "Color output: auto, always, or never")
+	rootCmd.PersistentFlags().StringVar(&logFormatFlag, "log-format", "pretty", "Background/diagnostic log output: pretty, plain, or json")
+	rootCmd.PersistentFlags().BoolVar(&noThemeFlag, "no-theme", false, "Disable the semantic color theme, printing plain text")
 
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(startCmd)
@@ -56,6 +160,14 @@ func init() {
 	rootCmd.AddCommand(doneCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(testJiraCmd)
+	rootCmd.AddCommand(profileCmd)
+	rootCmd.AddCommand(commentCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(linkCmd)
+	rootCmd.AddCommand(subtaskCmd)
+	rootCmd.AddCommand(parentCmd)
+	rootCmd.AddCommand(authCmd)
 }
 
 var initCmd = &cobra.Command{
@@ -84,16 +196,31 @@ var statusCmd = &cobra.Command{
 }
 
 var (
-	listStatus  string
-	listProject string
-	listJSON    bool
+	listStatus      string
+	listProject     string
+	listJSON        bool
+	listOffline     bool
+	listInteractive bool
 )
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List assigned Jira tickets",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return listHandler(listStatus, listProject, listJSON)
+		return listHandler(listStatus, listProject, listJSON, listOffline, listInteractive)
+	},
+}
+
+var (
+	syncDryRun   bool
+	syncConflict string
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Incrementally sync assigned tickets into the local offline cache",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return syncHandler(cmd.Context(), syncDryRun, syncConflict)
 	},
 }
 
@@ -143,11 +270,17 @@ var commitCmd = &cobra.Command{
 	},
 }
 
+var (
+	doneWaitCI    bool
+	doneCITimeout time.Duration
+	doneReviewers []string
+)
+
 var doneCmd = &cobra.Command{
 	Use:   "done",
 	Short: "Finalize work and create PR/MR",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return doneHandler()
+		return doneHandler(doneWaitCI, doneCITimeout, doneReviewers)
 	},
 }
 
@@ -189,6 +322,137 @@ var configPathCmd = &cobra.Command{
 	},
 }
 
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage credential profiles",
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured profiles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return profileListHandler()
+	},
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a new profile interactively",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return profileAddHandler(args[0])
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the active profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return profileUseHandler(args[0])
+	},
+}
+
+var profileRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return profileRemoveHandler(args[0])
+	},
+}
+
+var commentWithCommits bool
+
+var commentCmd = &cobra.Command{
+	Use:   "comment <text>",
+	Short: "Post a comment on the current branch's Jira ticket",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return commentHandler(strings.Join(args, " "), commentWithCommits)
+	},
+}
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show Jira comments interleaved with git history for the current branch",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return logHandler()
+	},
+}
+
+var linkCmd = &cobra.Command{
+	Use:   "link <ticket-a> <link-type> <ticket-b>",
+	Short: "Create a Jira issue link between two tickets",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return linkHandler(args[0], args[1], args[2])
+	},
+}
+
+var subtaskStart bool
+
+var subtaskCmd = &cobra.Command{
+	Use:   "subtask <summary>",
+	Short: "Create a subtask under the current branch's ticket",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return subtaskHandler(strings.Join(args, " "), subtaskStart)
+	},
+}
+
+var parentCmd = &cobra.Command{
+	Use:   "parent",
+	Short: "Show the parent ticket of the current branch's ticket",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return parentHandler()
+	},
+}
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage stored identities (multiple per host, independent of profiles)",
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login <target>",
+	Short: "Store a new identity for a target interactively",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return authLoginHandler(args[0])
+	},
+}
+
+var authListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stored identities",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return authListHandler()
+	},
+}
+
+var authRmCmd = &cobra.Command{
+	Use:   "rm <target> <user-id>",
+	Short: "Remove a stored identity",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return authRemoveHandler(args[0], args[1])
+	},
+}
+
+var authJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Jira-specific authentication helpers",
+}
+
+var authJiraOAuthSetupCmd = &cobra.Command{
+	Use:   "oauth-setup",
+	Short: "Walk the OAuth 1.0a request-token/authorize/access-token dance for a self-hosted Jira application link",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return authJiraOAuthSetupHandler()
+	},
+}
+
 var (
 	testJiraURL   string
 	testJiraEmail string
@@ -211,6 +475,8 @@ func init() {
 	listCmd.Flags().StringVar(&listStatus, "status", "", "Filter by status")
 	listCmd.Flags().StringVar(&listProject, "project", "", "Filter by project key")
 	listCmd.Flags().BoolVar(&listJSON, "json", false, "Output JSON")
+	listCmd.Flags().BoolVar(&listOffline, "offline", false, "Serve from the local cache without hitting the network")
+	listCmd.Flags().BoolVarP(&listInteractive, "interactive", "i", false, "Interactive mode")
 
 	searchCmd.Flags().StringVar(&searchOpts.Assignee, "assignee", "", "Filter by assignee")
 	searchCmd.Flags().StringVar(&searchOpts.Status, "status", "", "Filter by status")
@@ -218,13 +484,27 @@ func init() {
 	searchCmd.Flags().IntVar(&searchOpts.Limit, "limit", 10, "Maximum number of results")
 	searchCmd.Flags().BoolVarP(&searchOpts.Interactive, "interactive", "i", false, "Interactive mode")
 
+	doneCmd.Flags().BoolVar(&doneWaitCI, "wait-ci", false, "Wait for the pushed branch's CI to pass before creating the PR/MR")
+	doneCmd.Flags().DurationVar(&doneCITimeout, "ci-timeout", 15*time.Minute, "Maximum time to wait for CI with --wait-ci")
+	doneCmd.Flags().StringSliceVar(&doneReviewers, "reviewers", nil, "Usernames to request review from on the created PR/MR")
+
+	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show what would be imported/exported without changing the cache or Jira")
+	syncCmd.Flags().StringVar(&syncConflict, "conflict", "jira-wins", "How to resolve tickets changed both locally and in Jira: jira-wins, local-wins, or prompt")
+
 	openCmd.Flags().StringVar(&openTicket, "ticket", "", "Ticket ID")
 	openCmd.Flags().BoolVar(&openPR, "pr", false, "Open PR/MR instead of ticket")
 	openCmd.Flags().BoolVar(&openBoard, "board", false, "Open Jira board")
 
 	configCmd.AddCommand(configShowCmd, configSetCmd, configValidateCmd, configPathCmd)
+	profileCmd.AddCommand(profileListCmd, profileAddCmd, profileUseCmd, profileRemoveCmd)
+	authCmd.AddCommand(authLoginCmd, authListCmd, authRmCmd, authJiraCmd)
+	authJiraCmd.AddCommand(authJiraOAuthSetupCmd)
 
 	testJiraCmd.Flags().StringVar(&testJiraURL, "url", "", "Jira URL")
 	testJiraCmd.Flags().StringVar(&testJiraEmail, "email", "", "Jira email")
 	testJiraCmd.Flags().StringVar(&testJiraToken, "token", "", "Jira API token")
+
+	commentCmd.Flags().BoolVar(&commentWithCommits, "with-commits", false, "Include commit SHAs since branching off main")
+
+	subtaskCmd.Flags().BoolVar(&subtaskStart, "start", false, "Start work on the new subtask immediately")
 }
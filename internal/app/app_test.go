@@ -2,6 +2,7 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -9,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/Ilia01/devflow/internal/config"
+	"github.com/Ilia01/devflow/internal/jira"
 	"github.com/Ilia01/devflow/internal/models"
+	"github.com/Ilia01/devflow/internal/providers"
 )
 
 func TestStartCommandInvokesHandler(t *testing.T) {
@@ -88,11 +91,11 @@ func TestEndToEndWorkflow(t *testing.T) {
 	})
 	defer restoreJira()
 
-	fakeGitLab := &fakeGitLabClient{}
-	restoreGitLab := swapGitLabFactory(func(baseURL, token string) gitLabService {
-		return fakeGitLab
+	fakeGitLab := &fakeGitLabProvider{}
+	restoreGitProvider := swapGitProviderFactory(func(name string, cfg providers.Config) (providers.Provider, error) {
+		return fakeGitLab, nil
 	})
-	defer restoreGitLab()
+	defer restoreGitProvider()
 
 	settings := &config.Settings{
 		Jira: config.JiraConfig{
@@ -256,7 +259,7 @@ func newFakeJiraClient() *fakeJiraClient {
 	}
 }
 
-func (f *fakeJiraClient) GetTicket(ticketID string) (*models.JiraTicket, error) {
+func (f *fakeJiraClient) GetTicket(ticketID string, opts ...jira.FetchOptions) (*models.JiraTicket, error) {
 	return f.ticket, nil
 }
 
@@ -265,31 +268,87 @@ func (f *fakeJiraClient) UpdateStatus(ticketID, status string) error {
 	return nil
 }
 
-func (f *fakeJiraClient) SearchWithJQL(string, int) ([]models.JiraTicket, error) {
+func (f *fakeJiraClient) ListTransitions(ticketID string) ([]string, error) {
+	return []string{"To Do", "In Progress", "In Review", "Done"}, nil
+}
+
+func (f *fakeJiraClient) SearchWithJQL(string, int, ...jira.FetchOptions) ([]models.JiraTicket, error) {
 	return nil, nil
 }
 
+func (f *fakeJiraClient) SearchWithJQLPage(ctx context.Context, jql string, startAt, maxResults int, opts ...jira.FetchOptions) (jira.SearchPage, error) {
+	return jira.SearchPage{}, nil
+}
+
 func (f *fakeJiraClient) TestConnection() error {
 	return nil
 }
 
-type fakeGitLabClient struct {
+func (f *fakeJiraClient) GetComments(string) ([]models.JiraComment, error) {
+	return nil, nil
+}
+
+func (f *fakeJiraClient) AddComment(string, string) error {
+	return nil
+}
+
+func (f *fakeJiraClient) CreateIssueLink(inward, outward, linkType string) error {
+	return nil
+}
+
+func (f *fakeJiraClient) CreateSubtask(parentID string, fields jira.SubtaskFields) (*models.JiraTicket, error) {
+	return &models.JiraTicket{Key: parentID + "-1", Fields: models.TicketFields{Summary: fields.Summary}}, nil
+}
+
+func (f *fakeJiraClient) GetIssueLinks(ticketID string) ([]models.JiraLink, error) {
+	return nil, nil
+}
+
+type fakeGitLabProvider struct {
 	mergeCount int
 }
 
-func (f *fakeGitLabClient) CreateMergeRequest(projectPath, sourceBranch, targetBranch, title, description string) (string, error) {
+func (f *fakeGitLabProvider) Name() string { return "gitlab" }
+
+func (f *fakeGitLabProvider) Validate() error { return nil }
+
+func (f *fakeGitLabProvider) CreatePullRequest(sourceBranch, targetBranch, title, description string) (string, error) {
 	f.mergeCount++
 	return "https://gitlab.example.com/mr/1", nil
 }
 
+func (f *fakeGitLabProvider) PullRequestURL(branch string) string {
+	return "https://gitlab.example.com/merge_requests"
+}
+
+func (f *fakeGitLabProvider) GetPullRequest(id string) (*providers.PullRequest, error) {
+	return nil, nil
+}
+
+func (f *fakeGitLabProvider) ListOpenPullRequests() ([]providers.PullRequest, error) {
+	return nil, nil
+}
+
+func (f *fakeGitLabProvider) TestConnection() error {
+	return nil
+}
+
+func (f *fakeGitLabProvider) AddReviewers(id string, reviewers []string) error {
+	return nil
+}
+
+func (f *fakeGitLabProvider) MergePullRequest(id string) error {
+	return nil
+}
+
 func swapJiraFactory(fn func(string, string, config.AuthMethod) jiraService) func() {
 	orig := jiraFactory
 	jiraFactory = fn
 	return func() { jiraFactory = orig }
 }
 
-func swapGitLabFactory(fn func(string, string) gitLabService) func() {
-	orig := gitLabFactory
-	gitLabFactory = fn
-	return func() { gitLabFactory = orig }
+func swapGitProviderFactory(fn func(string, providers.Config) (providers.Provider, error)) func() {
+	orig := gitProviderFactory
+	gitProviderFactory = fn
+	return func() { gitProviderFactory = orig }
 }
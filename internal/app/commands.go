@@ -1,38 +1,50 @@
 package app
 
 import (
-	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"golang.org/x/term"
+
+	"github.com/Ilia01/devflow/internal/auth"
+	"github.com/Ilia01/devflow/internal/cache"
 	"github.com/Ilia01/devflow/internal/config"
 	"github.com/Ilia01/devflow/internal/git"
 	"github.com/Ilia01/devflow/internal/jira"
+	"github.com/Ilia01/devflow/internal/jira/jql"
+	"github.com/Ilia01/devflow/internal/logging"
 	"github.com/Ilia01/devflow/internal/models"
-	githubProvider "github.com/Ilia01/devflow/internal/providers/github"
-	gitlabProvider "github.com/Ilia01/devflow/internal/providers/gitlab"
+	"github.com/Ilia01/devflow/internal/prompt"
+	"github.com/Ilia01/devflow/internal/providers"
+	_ "github.com/Ilia01/devflow/internal/providers/bitbucket"
+	_ "github.com/Ilia01/devflow/internal/providers/gitea"
+	_ "github.com/Ilia01/devflow/internal/providers/github"
+	_ "github.com/Ilia01/devflow/internal/providers/gitlab"
+	"github.com/Ilia01/devflow/internal/theme"
+	"github.com/Ilia01/devflow/internal/tui"
 	"github.com/Ilia01/devflow/internal/utils"
 )
 
 type jiraService interface {
-	GetTicket(string) (*models.JiraTicket, error)
+	GetTicket(string, ...jira.FetchOptions) (*models.JiraTicket, error)
 	UpdateStatus(string, string) error
-	SearchWithJQL(string, int) ([]models.JiraTicket, error)
+	ListTransitions(string) ([]string, error)
+	SearchWithJQL(string, int, ...jira.FetchOptions) ([]models.JiraTicket, error)
+	SearchWithJQLPage(ctx context.Context, jql string, startAt, maxResults int, opts ...jira.FetchOptions) (jira.SearchPage, error)
 	TestConnection() error
-}
-
-type gitHubService interface {
-	CreatePullRequest(sourceBranch, targetBranch, title, description string) (string, error)
-}
-
-type gitLabService interface {
-	CreateMergeRequest(projectPath, sourceBranch, targetBranch, title, description string) (string, error)
+	GetComments(string) ([]models.JiraComment, error)
+	AddComment(string, string) error
+	CreateIssueLink(inward, outward, linkType string) error
+	CreateSubtask(parentID string, fields jira.SubtaskFields) (*models.JiraTicket, error)
+	GetIssueLinks(ticketID string) ([]models.JiraLink, error)
 }
 
 var (
@@ -40,12 +52,8 @@ var (
 		return jira.NewClient(url, email, auth)
 	}
 
-	gitHubFactory = func(owner, repo, token string) gitHubService {
-		return githubProvider.NewClient(owner, repo, token)
-	}
-
-	gitLabFactory = func(baseURL, token string) gitLabService {
-		return gitlabProvider.NewClient(baseURL, token)
+	gitProviderFactory = func(name string, cfg providers.Config) (providers.Provider, error) {
+		return providers.New(name, cfg)
 	}
 )
 
@@ -56,11 +64,11 @@ func handleInit() error {
 	fmt.Println(utils.Dim("The file will be created with read-only permissions (600)"))
 	fmt.Println()
 
-	jiraURL, err := utils.Prompt("Jira URL (e.g., https://jira.<company>.com)")
+	jiraURL, err := prompt.Prompt("Jira URL (e.g., https://jira.<company>.com)", prompt.Required, prompt.IsURL)
 	if err != nil {
 		return err
 	}
-	jiraEmail, err := utils.Prompt("Jira email")
+	jiraEmail, err := prompt.Prompt("Jira email", prompt.Required, prompt.IsEmail)
 	if err != nil {
 		return err
 	}
@@ -69,68 +77,107 @@ func handleInit() error {
 	fmt.Println(utils.Bold("Select authentication method:"))
 	fmt.Println(utils.Dim("  1. Personal Access Token (for Jira Data Center/Server)"))
 	fmt.Println(utils.Dim("  2. API Token (for Jira Cloud)"))
-	authChoice, err := utils.PromptWithDefault("Choice (1/2)", "2")
+	fmt.Println(utils.Dim("  3. OAuth 1.0a (for Jira Data Center/Server behind SSO)"))
+	authChoice, err := prompt.PromptChoice("Choice", []string{"1", "2", "3"}, "2")
 	if err != nil {
 		return err
 	}
 
 	var auth config.AuthMethod
-	if authChoice == "1" {
+	switch authChoice {
+	case "1":
 		fmt.Println()
 		fmt.Println(utils.Dim("To create a Personal Access Token:"))
 		fmt.Println(utils.Dim("  1. Go to Jira → Profile → Personal Access Tokens"))
 		fmt.Println(utils.Dim("  2. Click 'Create token'"))
 		fmt.Println(utils.Dim("  3. Copy and paste it here"))
-		token, err := utils.PromptPassword("Personal Access Token")
+		token, err := prompt.PromptPassword("Personal Access Token", prompt.Required)
 		if err != nil {
 			return err
 		}
 		auth = config.AuthMethod{Type: "personal_access_token", Token: token}
-	} else {
+	case "3":
+		auth, err = runOAuth1Setup(jiraURL)
+		if err != nil {
+			return err
+		}
+	default:
 		fmt.Println()
 		fmt.Println(utils.Dim("To create a Jira API token:"))
 		fmt.Println(utils.Dim("  1. Go to https://id.atlassian.com/manage-profile/security/api-tokens"))
 		fmt.Println(utils.Dim("  2. Click 'Create API token'"))
 		fmt.Println(utils.Dim("  3. Copy and paste it here"))
-		token, err := utils.PromptPassword("Jira API token")
+		token, err := prompt.PromptPassword("Jira API token", prompt.Required)
 		if err != nil {
 			return err
 		}
 		auth = config.AuthMethod{Type: "api_token", Token: token}
 	}
 
-	projectKey, err := utils.Prompt("Default project key (e.g., WAB)")
+	projectKey, err := prompt.Prompt("Default project key (e.g., WAB)", prompt.Required)
 	if err != nil {
 		return err
 	}
 
 	fmt.Println()
 	fmt.Println(utils.Bold("=== Git Configuration ==="))
-	gitProvider, err := utils.PromptWithDefault("Git provider (gitlab/github)", "gitlab")
+	gitProvider, err := prompt.PromptChoice("Git provider", providers.Names(), "gitlab")
 	if err != nil {
 		return err
 	}
 	gitProvider = strings.ToLower(strings.TrimSpace(gitProvider))
 
-	var gitBaseURL, gitOwner, gitRepo string
-	if gitProvider == "github" {
+	var gitBaseURL, gitOwner, gitRepo, gitAPIVersion string
+	switch gitProvider {
+	case "github":
 		fmt.Println()
 		fmt.Println(utils.Dim("For GitHub, create a token at:"))
 		fmt.Println(utils.Dim("  Settings > Developer settings > Personal access tokens"))
 		fmt.Println(utils.Dim("  Required scope: repo (full control)"))
-		owner, err := utils.Prompt("Repository owner (username or org)")
+		owner, err := prompt.Prompt("Repository owner (username or org)", prompt.Required)
 		if err != nil {
 			return err
 		}
-		repo, err := utils.Prompt("Repository name")
+		repo, err := prompt.Prompt("Repository name", prompt.Required)
 		if err != nil {
 			return err
 		}
 		gitBaseURL = "https://api.github.com"
 		gitOwner = owner
 		gitRepo = repo
-	} else {
-		url, err := utils.Prompt("GitLab base URL (e.g., https://git.<company>.com)")
+	case "bitbucket":
+		fmt.Println()
+		fmt.Println(utils.Dim("For Bitbucket, create an app password at:"))
+		fmt.Println(utils.Dim("  Personal settings > App passwords (scope: Pull requests)"))
+		workspace, err := prompt.Prompt("Workspace slug", prompt.Required)
+		if err != nil {
+			return err
+		}
+		repo, err := prompt.Prompt("Repository name", prompt.Required)
+		if err != nil {
+			return err
+		}
+		gitBaseURL = "https://api.bitbucket.org"
+		gitOwner = workspace
+		gitRepo = repo
+	case "gitea":
+		url, err := prompt.Prompt("Gitea base URL (e.g., https://gitea.<company>.com)", prompt.Required, prompt.IsURL)
+		if err != nil {
+			return err
+		}
+		owner, err := prompt.Prompt("Repository owner", prompt.Required)
+		if err != nil {
+			return err
+		}
+		repo, err := prompt.Prompt("Repository name", prompt.Required)
+		if err != nil {
+			return err
+		}
+		gitBaseURL = url
+		gitOwner = owner
+		gitRepo = repo
+	default:
+		url, err := prompt.Prompt("GitLab base URL (e.g., https://git.<company>.com)", prompt.Required, prompt.IsURL)
 		if err != nil {
 			return err
 		}
@@ -139,20 +186,28 @@ func handleInit() error {
 		fmt.Println(utils.Dim("  Settings > Access Tokens"))
 		fmt.Println(utils.Dim("  Required scopes: api"))
 		gitBaseURL = url
+
+		version, err := prompt.PromptDefault("GitLab API version (v4/v3, use v3 only for old self-hosted instances)", "v4")
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(version) != "v4" {
+			gitAPIVersion = strings.TrimSpace(version)
+		}
 	}
 
-	gitToken, err := utils.PromptPassword("Git API token")
+	gitToken, err := prompt.PromptPassword("Git API token", prompt.Required)
 	if err != nil {
 		return err
 	}
 
 	fmt.Println()
 	fmt.Println(utils.Bold("=== Preferences ==="))
-	branchPrefix, err := utils.PromptWithDefault("Branch prefix (feat/fix/test)", "feat")
+	branchPrefix, err := prompt.PromptDefault("Branch prefix (feat/fix/test)", "feat")
 	if err != nil {
 		return err
 	}
-	defaultTransition, err := utils.PromptWithDefault("Default Jira transition", "In Progress")
+	defaultTransition, err := prompt.PromptDefault("Default Jira transition", "In Progress")
 	if err != nil {
 		return err
 	}
@@ -165,11 +220,12 @@ func handleInit() error {
 			AuthMethod: auth,
 		},
 		Git: config.GitConfig{
-			Provider: gitProvider,
-			BaseURL:  strings.TrimSpace(gitBaseURL),
-			Token:    strings.TrimSpace(gitToken),
-			Owner:    strings.TrimSpace(gitOwner),
-			Repo:     strings.TrimSpace(gitRepo),
+			Provider:   gitProvider,
+			BaseURL:    strings.TrimSpace(gitBaseURL),
+			Token:      strings.TrimSpace(gitToken),
+			Owner:      strings.TrimSpace(gitOwner),
+			Repo:       strings.TrimSpace(gitRepo),
+			APIVersion: gitAPIVersion,
 		},
 		Preferences: config.Preferences{
 			BranchPrefix:      strings.TrimSpace(branchPrefix),
@@ -204,12 +260,27 @@ func handleInit() error {
 		fmt.Println(utils.Green("✓"))
 	}
 
-	fmt.Print(utils.Dim("  Checking Git token... "))
+	fmt.Print(utils.Dim("  Testing Git provider connection... "))
 	if settings.Git.Token == "" {
 		fmt.Println(utils.Red("✗"))
 		fmt.Println(utils.Yellow("  Warning: Git token is empty"))
 	} else {
-		fmt.Println(utils.Green("✓"))
+		projectPath := ""
+		if settings.Git.Owner != "" && settings.Git.Repo != "" {
+			projectPath = fmt.Sprintf("%s/%s", settings.Git.Owner, settings.Git.Repo)
+		}
+		gitProvider, err := gitProviderFactory(settings.Git.Provider, gitProviderConfig(settings, projectPath))
+		if err != nil {
+			fmt.Println(utils.Red("✗"))
+			fmt.Printf("  %s %v\n", utils.Yellow("Warning:"), err)
+		} else if err := gitProvider.TestConnection(); err != nil {
+			fmt.Println(utils.Red("✗"))
+			fmt.Println()
+			fmt.Printf("  %s %v\n", utils.Yellow("Warning:"), err)
+			fmt.Println(utils.Dim("  This may be expected if VPN/network restrictions apply."))
+		} else {
+			fmt.Println(utils.Green("✓"))
+		}
 	}
 
 	fmt.Println()
@@ -220,6 +291,54 @@ func handleInit() error {
 	return nil
 }
 
+func runOAuth1Setup(jiraURL string) (config.AuthMethod, error) {
+	fmt.Println()
+	fmt.Println(utils.Dim("OAuth 1.0a requires an application link configured on the Jira side."))
+	fmt.Println(utils.Dim("See Jira → Administration → Application Links for the consumer key/RSA keypair."))
+
+	consumerKey, err := prompt.Prompt("Consumer key", prompt.Required)
+	if err != nil {
+		return config.AuthMethod{}, err
+	}
+	privateKeyPath, err := prompt.Prompt("Path to PEM-encoded RSA private key", prompt.Required)
+	if err != nil {
+		return config.AuthMethod{}, err
+	}
+
+	fmt.Println()
+	fmt.Println(utils.Dim("  Requesting temporary token..."))
+	requestToken, requestSecret, authorizeURL, err := jira.RequestToken(jiraURL, consumerKey, privateKeyPath)
+	if err != nil {
+		return config.AuthMethod{}, fmt.Errorf("obtain request token: %w", err)
+	}
+
+	fmt.Println(utils.Cyan(fmt.Sprintf("  Opening %s", authorizeURL)))
+	if err := utils.OpenURL(authorizeURL); err != nil {
+		fmt.Println(utils.Yellow(fmt.Sprintf("  Could not open browser automatically: %v", err)))
+	}
+
+	verifier, err := prompt.Prompt("Paste the verifier code Jira gave you", prompt.Required)
+	if err != nil {
+		return config.AuthMethod{}, err
+	}
+
+	fmt.Println(utils.Dim("  Exchanging verifier for access token..."))
+	accessToken, tokenSecret, err := jira.AccessToken(jiraURL, consumerKey, privateKeyPath, requestToken, requestSecret, verifier)
+	if err != nil {
+		return config.AuthMethod{}, fmt.Errorf("obtain access token: %w", err)
+	}
+
+	fmt.Println(utils.Green("  ✓ OAuth 1.0a authorized"))
+
+	return config.AuthMethod{
+		Type:           "oauth1",
+		ConsumerKey:    consumerKey,
+		PrivateKeyPath: privateKeyPath,
+		AccessToken:    accessToken,
+		TokenSecret:    tokenSecret,
+	}, nil
+}
+
 func handleStart(ticketID string) error {
 	settings, err := loadSettings()
 	if err != nil {
@@ -233,13 +352,13 @@ func handleStart(ticketID string) error {
 
 	if branch, err := gitClient.CurrentBranch(); err == nil {
 		if strings.Contains(strings.ToUpper(branch), strings.ToUpper(ticketID)) {
-			fmt.Println(utils.Yellow(fmt.Sprintf("Already on branch: %s", branch)))
+			fmt.Println(theme.Render(theme.RoleWarn, fmt.Sprintf("Already on branch: %s", branch)))
 			fmt.Println(utils.Dim("Run 'devflow status' to see current state"))
 			return nil
 		}
 	}
 
-	fmt.Println(utils.Cyan(utils.Bold(fmt.Sprintf("Starting work on %s...", ticketID))))
+	fmt.Println(theme.Render(theme.RoleHeading, utils.Bold(fmt.Sprintf("Starting work on %s...", ticketID))))
 	fmt.Println()
 	fmt.Println(utils.Dim("  Fetching Jira ticket..."))
 
@@ -249,17 +368,21 @@ func handleStart(ticketID string) error {
 		return err
 	}
 
-	fmt.Println(utils.Green(fmt.Sprintf("  ✓ Found: %s", ticket.Fields.Summary)))
+	fmt.Println(theme.Render(theme.RoleSuccess, fmt.Sprintf("  ✓ Found: %s", ticket.Fields.Summary)))
 	fmt.Println(utils.Dim(fmt.Sprintf("    Status: %s", ticket.Fields.Status.Name)))
 
 	prefix := settings.Preferences.BranchPrefix
 	if prefix == "" {
 		prefix = "feat"
 	}
-	branchName := utils.FormatBranchName(prefix, ticketID, ticket.Fields.Summary)
+	component := ""
+	if len(ticket.Fields.Components) > 0 {
+		component = ticket.Fields.Components[0].Name
+	}
+	branchName := utils.FormatBranchName(prefix, ticketID, ticket.Fields.Summary, component)
 
 	fmt.Println()
-	fmt.Println(utils.Cyan(fmt.Sprintf("  Creating branch: %s", branchName)))
+	fmt.Println(theme.Render(theme.RoleBranch, fmt.Sprintf("  Creating branch: %s", branchName)))
 	if err := gitClient.CreateBranch(branchName); err != nil {
 		return err
 	}
@@ -267,15 +390,11 @@ func handleStart(ticketID string) error {
 	transition := settings.Preferences.DefaultTransition
 	if transition != "" {
 		fmt.Println(utils.Cyan(fmt.Sprintf("  Updating Jira status to '%s'...", transition)))
-		if err := jiraClient.UpdateStatus(ticketID, transition); err != nil {
-			fmt.Println(utils.Yellow(fmt.Sprintf("  Could not update status: %v", err)))
-		} else {
-			fmt.Println(utils.Green(fmt.Sprintf("  ✓ Status updated to '%s'", transition)))
-		}
+		applyStatusChangeThenPush(ticket, transition, jiraClient)
 	}
 
 	fmt.Println()
-	fmt.Println(utils.Green(utils.Bold("✨ All set! You're ready to code!")))
+	fmt.Println(theme.Render(theme.RoleSuccess, utils.Bold("✨ All set! You're ready to code!")))
 	fmt.Println()
 	fmt.Printf("  %s %s\n", utils.Bold("Ticket:"), utils.BrightWhite(ticketID))
 	fmt.Printf("  %s %s\n", utils.Bold("Branch:"), utils.BrightWhite(branchName))
@@ -285,7 +404,7 @@ func handleStart(ticketID string) error {
 }
 
 func handleStatus() error {
-	fmt.Println(utils.Cyan("Current Status"))
+	fmt.Println(theme.Render(theme.RoleHeading, "Current Status"))
 	fmt.Println()
 
 	gitClient, err := git.NewClient()
@@ -298,42 +417,106 @@ func handleStatus() error {
 	if branch, err := gitClient.CurrentBranch(); err == nil {
 		fmt.Printf("  %s %s\n", utils.Bold("Branch:"), utils.BrightWhite(branch))
 	} else {
-		fmt.Printf("  %s %s\n", utils.Bold("Branch:"), utils.Red(err.Error()))
+		fmt.Printf("  %s %s\n", utils.Bold("Branch:"), theme.Render(theme.RoleError, err.Error()))
 	}
 
 	if summary, err := gitClient.StatusSummary(); err == nil {
 		fmt.Printf("\n  %s:\n%s\n", utils.Bold("Status"), summary)
 	} else {
-		fmt.Printf("  %s %s\n", utils.Bold("Status:"), utils.Red(err.Error()))
+		fmt.Printf("  %s %s\n", utils.Bold("Status:"), theme.Render(theme.RoleError, err.Error()))
 	}
 
+	printTicketRelationships(gitClient)
+
 	return nil
 }
-func handleList(statusFilter, projectFilter string, jsonOutput bool) error {
-	settings, err := loadSettings()
+
+// printTicketRelationships best-effort prints the parent/subtask and issue
+// link context for the current branch's ticket. Any failure here (no
+// ticket in the branch name, no config, network error) is silently
+// swallowed since this is supplementary to the git status output above.
+func printTicketRelationships(gitClient *git.Client) {
+	branch, err := gitClient.CurrentBranch()
 	if err != nil {
-		return err
+		return
+	}
+	ticketID, err := utils.ExtractTicketID(branch)
+	if err != nil {
+		return
 	}
 
+	settings, err := loadSettings()
+	if err != nil {
+		return
+	}
 	jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
+	ticket, err := jiraClient.GetTicket(ticketID)
+	if err != nil {
+		return
+	}
+
+	if ticket.Fields.Parent == nil && len(ticket.Fields.IssueLinks) == 0 {
+		return
+	}
+
+	fmt.Printf("\n  %s\n", utils.Bold("Relationships"))
+	if ticket.Fields.Parent != nil {
+		fmt.Printf("    %s %s %s\n", utils.Dim("parent:"), utils.BrightWhite(ticket.Fields.Parent.Key), utils.Dim(ticket.Fields.Parent.Fields.Summary))
+	}
+	for _, link := range ticket.Fields.IssueLinks {
+		if link.OutwardIssue != nil {
+			fmt.Printf("    %s %s %s\n", utils.Dim(link.Type.Outward+":"), utils.BrightWhite(link.OutwardIssue.Key), utils.Dim(link.OutwardIssue.Fields.Summary))
+		}
+		if link.InwardIssue != nil {
+			fmt.Printf("    %s %s %s\n", utils.Dim(link.Type.Inward+":"), utils.BrightWhite(link.InwardIssue.Key), utils.Dim(link.InwardIssue.Fields.Summary))
+		}
+	}
+}
+func handleList(statusFilter, projectFilter string, jsonOutput, offline, interactive bool) error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
 
 	project := strings.TrimSpace(projectFilter)
 	if project == "" {
 		project = settings.Jira.ProjectKey
 	}
 
-	jqlParts := []string{"assignee = currentUser()"}
-	if project != "" {
-		jqlParts = append(jqlParts, fmt.Sprintf("project = %s", project))
+	ttl := cache.DefaultTTL
+	if settings.Preferences.CacheTTLMinutes > 0 {
+		ttl = time.Duration(settings.Preferences.CacheTTLMinutes) * time.Minute
 	}
-	if statusFilter != "" {
-		jqlParts = append(jqlParts, fmt.Sprintf("status = \"%s\"", statusFilter))
+
+	var tickets []models.JiraTicket
+
+	store, cacheErr := cache.Open()
+	useCache := offline
+	if cacheErr == nil && !offline && store.IsFresh(project, ttl) {
+		useCache = true
 	}
 
-	jql := strings.Join(jqlParts, " AND ")
-	tickets, err := jiraClient.SearchWithJQL(jql, 50)
-	if err != nil {
-		return err
+	jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
+
+	if useCache && cacheErr == nil {
+		tickets = store.List(project, statusFilter)
+	} else {
+		clauses := []jql.Clause{jql.Raw("assignee = currentUser()")}
+		if project != "" {
+			clauses = append(clauses, jql.Eq(jql.FieldProject, project))
+		}
+		if statusFilter != "" {
+			clauses = append(clauses, jql.Eq(jql.FieldStatus, statusFilter))
+		}
+
+		query, err := jql.And(clauses...).Render()
+		if err != nil {
+			return err
+		}
+		tickets, err = jiraClient.SearchWithJQL(query, 50)
+		if err != nil {
+			return err
+		}
 	}
 
 	if jsonOutput {
@@ -345,6 +528,10 @@ func handleList(statusFilter, projectFilter string, jsonOutput bool) error {
 		return nil
 	}
 
+	if interactive && stdoutIsTerminal() {
+		return runTicketTUI(settings, jiraClient, tickets)
+	}
+
 	fmt.Println(utils.Cyan(utils.Bold("Your Assigned Tickets")))
 	fmt.Println()
 
@@ -379,33 +566,36 @@ func handleSearch(opts searchOptions) error {
 	fmt.Println(utils.Cyan(utils.Bold(fmt.Sprintf("Searching for: \"%s\"", opts.Query))))
 	fmt.Println()
 
-	jqlParts := []string{fmt.Sprintf("(summary ~ \"%s\" OR description ~ \"%s\")", escapeJQL(opts.Query), escapeJQL(opts.Query))}
+	clauses := []jql.Clause{jql.Or(jql.Like(jql.FieldSummary, opts.Query), jql.Like(jql.FieldDescription, opts.Query))}
 
 	project := strings.TrimSpace(opts.Project)
 	if project == "" {
 		project = settings.Jira.ProjectKey
 	}
 	if project != "" {
-		jqlParts = append(jqlParts, fmt.Sprintf("project = %s", project))
+		clauses = append(clauses, jql.Eq(jql.FieldProject, project))
 	}
 
 	if opts.Assignee != "" {
 		if opts.Assignee == "me" {
-			jqlParts = append(jqlParts, "assignee = currentUser()")
+			clauses = append(clauses, jql.Raw("assignee = currentUser()"))
 		} else {
-			jqlParts = append(jqlParts, fmt.Sprintf("assignee = \"%s\"", opts.Assignee))
+			clauses = append(clauses, jql.Eq(jql.FieldAssignee, opts.Assignee))
 		}
 	}
 
 	if opts.Status != "" {
-		jqlParts = append(jqlParts, fmt.Sprintf("status = \"%s\"", opts.Status))
+		clauses = append(clauses, jql.Eq(jql.FieldStatus, opts.Status))
 	}
 
-	jql := strings.Join(jqlParts, " AND ")
-	fmt.Println(utils.Dim(fmt.Sprintf("  JQL: %s", jql)))
+	query, err := jql.And(clauses...).Render()
+	if err != nil {
+		return err
+	}
+	fmt.Println(utils.Dim(fmt.Sprintf("  JQL: %s", query)))
 	fmt.Println()
 
-	tickets, err := jiraClient.SearchWithJQL(jql, opts.Limit)
+	tickets, err := jiraClient.SearchWithJQL(query, opts.Limit)
 	if err != nil {
 		return err
 	}
@@ -415,6 +605,10 @@ func handleSearch(opts searchOptions) error {
 		return nil
 	}
 
+	if opts.Interactive && stdoutIsTerminal() {
+		return runTicketTUI(settings, jiraClient, tickets)
+	}
+
 	for i, ticket := range tickets {
 		fmt.Printf("  %s. %s [%s]  %s\n",
 			utils.Dim(strconv.Itoa(i+1)),
@@ -447,6 +641,47 @@ func handleSearch(opts searchOptions) error {
 	return nil
 }
 
+// stdoutIsTerminal reports whether stdout is attached to a terminal, the
+// same check internal/prompt uses for stdin, mirrored here since the TUI
+// needs a real screen to render into.
+func stdoutIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// runTicketTUI converts tickets into tui.Ticket and launches the shared
+// interactive browser, wiring its actions back into the same Jira client
+// and handlers the non-interactive commands use.
+func runTicketTUI(settings *config.Settings, jiraClient jiraService, tickets []models.JiraTicket) error {
+	tuiTickets := make([]tui.Ticket, len(tickets))
+	for i, t := range tickets {
+		tuiTickets[i] = tui.Ticket{
+			Key:     t.Key,
+			Status:  t.Fields.Status.Name,
+			Summary: t.Fields.Summary,
+		}
+	}
+
+	deps := tui.Deps{
+		Open: func(t tui.Ticket) error {
+			return utils.OpenURL(fmt.Sprintf("%s/browse/%s", settings.Jira.URL, t.Key))
+		},
+		Start: func(t tui.Ticket) error {
+			return handleStart(t.Key)
+		},
+		Transitions: func(ticketKey string) ([]string, error) {
+			return jiraClient.ListTransitions(ticketKey)
+		},
+		Transition: func(ticketKey, transitionName string) error {
+			return jiraClient.UpdateStatus(ticketKey, transitionName)
+		},
+		Yank: func(t tui.Ticket) error {
+			return utils.CopyToClipboard(t.Key)
+		},
+	}
+
+	return tui.Run(tuiTickets, deps)
+}
+
 func handleOpen(ticketID string, openPR, openBoard bool) error {
 	settings, err := loadSettings()
 	if err != nil {
@@ -484,23 +719,17 @@ func handleOpen(ticketID string, openPR, openBoard bool) error {
 			return err
 		}
 
-		provider := strings.ToLower(settings.Git.Provider)
-		switch provider {
-		case "github":
-			if settings.Git.Owner == "" || settings.Git.Repo == "" {
-				return errors.New("GitHub owner/repo not configured")
-			}
-			base := strings.Replace(strings.TrimSuffix(settings.Git.BaseURL, "/"), "api.", "", 1)
-			prURL := fmt.Sprintf("%s/%s/%s/pulls?q=is%%3Apr+head%%3A%s", base, settings.Git.Owner, settings.Git.Repo, url.QueryEscape(branch))
-			fmt.Printf("%s %s\n", utils.Dim("Opening PR:"), utils.BrightWhite(prURL))
-			return utils.OpenURL(prURL)
-		case "gitlab":
-			prURL := fmt.Sprintf("%s/merge_requests?scope=all&state=opened&source_branch=%s", strings.TrimSuffix(settings.Git.BaseURL, "/"), url.QueryEscape(branch))
-			fmt.Printf("%s %s\n", utils.Dim("Opening MR:"), utils.BrightWhite(prURL))
-			return utils.OpenURL(prURL)
-		default:
-			return fmt.Errorf("unsupported git provider: %s", provider)
+		provider, err := gitProviderFactory(settings.Git.Provider, gitProviderConfig(settings, ""))
+		if err != nil {
+			return err
+		}
+		if err := provider.Validate(); err != nil {
+			return err
 		}
+
+		prURL := provider.PullRequestURL(branch)
+		fmt.Printf("%s %s\n", utils.Dim(fmt.Sprintf("Opening %s:", prNoun(provider.Name()))), utils.BrightWhite(prURL))
+		return utils.OpenURL(prURL)
 	}
 
 	ticketURL := fmt.Sprintf("%s/browse/%s", settings.Jira.URL, ticketID)
@@ -533,14 +762,23 @@ func handleCommit(message string) error {
 	}
 
 	fmt.Println()
-	fmt.Println(utils.Green(utils.Bold("Commit created successfully!")))
+	fmt.Println(theme.Render(theme.RoleSuccess, utils.Bold("Commit created successfully!")))
 	fmt.Printf("  %s %s\n", utils.Bold("Message:"), message)
 	fmt.Printf("  %s %s\n", utils.Bold("Ticket:"), utils.BrightWhite(ticketID))
 
+	if settings.Preferences.AutoCommentOnCommit {
+		jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
+		if err := jiraClient.AddComment(ticketID, message); err != nil {
+			fmt.Println(utils.Yellow(fmt.Sprintf("  Could not post commit comment: %v", err)))
+		} else {
+			fmt.Println(utils.Dim("  Posted commit message as a Jira comment"))
+		}
+	}
+
 	return nil
 }
 
-func handleDone() error {
+func handleComment(message string, withCommits bool) error {
 	settings, err := loadSettings()
 	if err != nil {
 		return err
@@ -550,15 +788,58 @@ func handleDone() error {
 	if err != nil {
 		return err
 	}
-
-	clean, err := gitClient.IsClean()
+	branch, err := gitClient.CurrentBranch()
 	if err != nil {
 		return err
 	}
-	if !clean {
-		return errors.New("uncommitted changes detected. Commit or stash before running 'devflow done'")
+	ticketID, err := utils.ExtractTicketID(branch)
+	if err != nil {
+		return err
+	}
+
+	body := message
+	if withCommits {
+		commits, err := gitClient.CommitsSince("main")
+		if err != nil {
+			fmt.Println(utils.Yellow(fmt.Sprintf("  Could not list commits: %v", err)))
+		} else if len(commits) > 0 {
+			var b strings.Builder
+			b.WriteString(message)
+			b.WriteString("\n\nCommits:\n")
+			for _, commit := range commits {
+				fmt.Fprintf(&b, "- %s %s\n", commit.SHA[:7], commit.Subject)
+			}
+			body = b.String()
+		}
+	}
+
+	jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
+	if err := jiraClient.AddComment(ticketID, body); err != nil {
+		if store, cacheErr := cache.Open(); cacheErr == nil {
+			store.QueuePending(cache.PendingChange{TicketKey: ticketID, Kind: cache.PendingComment, Value: body, QueuedAt: time.Now()})
+			if saveErr := store.Save(); saveErr == nil {
+				fmt.Println(utils.Yellow(fmt.Sprintf("  Could not post comment now (%v); queued for next 'devflow sync'", err)))
+				return nil
+			}
+		}
+		return err
+	}
+
+	fmt.Println(utils.Green(utils.Bold("Comment posted!")))
+	fmt.Printf("  %s %s\n", utils.Bold("Ticket:"), utils.BrightWhite(ticketID))
+	return nil
+}
+
+func handleLog() error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
 	}
 
+	gitClient, err := git.NewClient()
+	if err != nil {
+		return err
+	}
 	branch, err := gitClient.CurrentBranch()
 	if err != nil {
 		return err
@@ -568,124 +849,566 @@ func handleDone() error {
 		return err
 	}
 
-	fmt.Println(utils.Cyan(utils.Bold("Finalizing work...")))
-	fmt.Println()
-	fmt.Println(utils.Dim("  Pushing branch to remote..."))
-	if err := gitClient.Push(branch); err != nil {
+	commits, err := gitClient.Log("HEAD", 50)
+	if err != nil {
 		return err
 	}
 
 	jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
-	fmt.Println(utils.Dim("  Fetching ticket information..."))
-	ticket, err := jiraClient.GetTicket(ticketID)
+	comments, err := jiraClient.GetComments(ticketID)
 	if err != nil {
 		return err
 	}
 
-	prTitle := fmt.Sprintf("%s: %s", ticketID, ticket.Fields.Summary)
-	prDescription := fmt.Sprintf("Resolves %s\n\nJira: %s/browse/%s", ticketID, settings.Jira.URL, ticketID)
-
-	provider := strings.ToLower(settings.Git.Provider)
-	var prURL string
-	switch provider {
-	case "github":
-		if settings.Git.Owner == "" || settings.Git.Repo == "" {
-			return errors.New("GitHub owner/repo not configured")
-		}
-		fmt.Println(utils.Dim("  Creating pull request..."))
-		client := gitHubFactory(settings.Git.Owner, settings.Git.Repo, settings.Git.Token)
-		prURL, err = client.CreatePullRequest(branch, "main", prTitle, prDescription)
-	case "gitlab":
-		fmt.Println(utils.Dim("  Creating merge request..."))
-		projectPath := filepath.Base(gitClient.Root())
-		client := gitLabFactory(settings.Git.BaseURL, settings.Git.Token)
-		prURL, err = client.CreateMergeRequest(projectPath, branch, "main", prTitle, prDescription)
-	default:
-		return fmt.Errorf("unsupported git provider: %s", provider)
+	entries := make([]logEntry, 0, len(commits)+len(comments))
+	for _, commit := range commits {
+		entries = append(entries, logEntry{
+			at:   commit.Timestamp,
+			line: fmt.Sprintf("%s %s %s", utils.Dim("commit"), theme.Render(theme.RoleCommit, commit.SHA[:7]), commit.Subject),
+		})
 	}
-	if err != nil {
-		return err
+	for _, comment := range comments {
+		entries = append(entries, logEntry{
+			at:   comment.Created.Time,
+			line: fmt.Sprintf("%s %s: %s", utils.Dim("comment"), utils.Blue(comment.Author.DisplayName), comment.Body),
+		})
 	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].at.After(entries[j].at) })
 
-	fmt.Println(utils.Dim("  Updating Jira status to 'In Review'..."))
-	if err := jiraClient.UpdateStatus(ticketID, "In Review"); err != nil {
-		fmt.Println(utils.Yellow(fmt.Sprintf("  Could not update status: %v", err)))
-	} else {
-		fmt.Println(utils.Green("  ✓ Status updated to 'In Review'"))
+	fmt.Println(utils.Cyan(utils.Bold(fmt.Sprintf("History for %s", ticketID))))
+	fmt.Println()
+	for _, entry := range entries {
+		fmt.Printf("  %s  %s\n", utils.Dim(entry.at.Format("2006-01-02 15:04")), entry.line)
 	}
+	return nil
+}
 
-	label := "PR"
-	if provider == "gitlab" {
-		label = "MR"
+type logEntry struct {
+	at   time.Time
+	line string
+}
+
+func handleLink(ticketA, linkType, ticketB string) error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
 	}
 
-	fmt.Println()
-	fmt.Println(utils.Green(utils.Bold("All done! Ready for review!")))
-	fmt.Printf("  %s %s\n", utils.Bold("Ticket:"), utils.BrightWhite(ticketID))
-	fmt.Printf("  %s %s\n", utils.Bold("Branch:"), utils.BrightWhite(branch))
-	fmt.Printf("  %s %s\n", utils.Bold(label+":"), utils.Cyan(prURL))
+	jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
+	if err := jiraClient.CreateIssueLink(ticketB, ticketA, linkType); err != nil {
+		return err
+	}
 
+	fmt.Println(utils.Green(utils.Bold("Link created!")))
+	fmt.Printf("  %s %s %s\n", utils.BrightWhite(ticketA), utils.Dim(linkType), utils.BrightWhite(ticketB))
 	return nil
 }
 
-func handleConfigShow() error {
+func handleSubtask(summary string, start bool) error {
 	settings, err := loadSettings()
 	if err != nil {
 		return err
 	}
-	printConfig(settings)
-	return nil
-}
 
-func handleConfigSet(key, value string) error {
-	settings, err := loadSettings()
+	gitClient, err := git.NewClient()
 	if err != nil {
 		return err
 	}
-	if err := updateConfigValue(settings, key, value); err != nil {
+	branch, err := gitClient.CurrentBranch()
+	if err != nil {
 		return err
 	}
-	if err := settings.Save(); err != nil {
+	parentID, err := utils.ExtractTicketID(branch)
+	if err != nil {
 		return err
 	}
-	fmt.Println(utils.Green(utils.Bold(fmt.Sprintf("✓ Updated %s to: %s", key, value))))
-	return nil
-}
 
-func handleConfigValidate() error {
-	settings, err := loadSettings()
+	jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
+	parent, err := jiraClient.GetTicket(parentID)
 	if err != nil {
 		return err
 	}
-	fmt.Println(utils.Cyan(utils.Bold("Validating configuration...")))
-	fmt.Println()
-	fmt.Print(utils.Dim("  Testing Jira connection... "))
-	jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
-	if err := jiraClient.TestConnection(); err != nil {
-		fmt.Println(utils.Red("✗"))
-		fmt.Println(utils.Yellow(fmt.Sprintf("  Jira validation failed: %v", err)))
-	} else {
-		fmt.Println(utils.Green("✓"))
+
+	fields := jira.SubtaskFields{
+		Summary:    summary,
+		ProjectKey: settings.Jira.ProjectKey,
 	}
-	if settings.Git.Token == "" {
-		fmt.Println(utils.Yellow("  Warning: Git token is empty"))
-	} else {
-		fmt.Println(utils.Dim("  Git token configured"))
+	if i := strings.LastIndex(parentID, "-"); i > 0 {
+		fields.ProjectKey = parentID[:i]
+	}
+	if parent.Fields.Assignee != nil {
+		fields.Assignee = parent.Fields.Assignee.DisplayName
 	}
-	return nil
-}
 
-func handleConfigPath() error {
-	path, err := config.ConfigPath()
+	subtask, err := jiraClient.CreateSubtask(parentID, fields)
 	if err != nil {
 		return err
 	}
-	fmt.Println(path)
+
+	fmt.Println(utils.Green(utils.Bold("Subtask created!")))
+	fmt.Printf("  %s %s\n", utils.Bold("Ticket:"), utils.BrightWhite(subtask.Key))
+	fmt.Printf("  %s %s\n", utils.Bold("Parent:"), utils.BrightWhite(parentID))
+
+	if start {
+		return startHandler(subtask.Key)
+	}
 	return nil
 }
 
-func handleTestJira(ticketID, jiraURL, email, token string) error {
-	if ticketID == "" || jiraURL == "" || email == "" || token == "" {
+func handleParent() error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+
+	gitClient, err := git.NewClient()
+	if err != nil {
+		return err
+	}
+	branch, err := gitClient.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	ticketID, err := utils.ExtractTicketID(branch)
+	if err != nil {
+		return err
+	}
+
+	jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
+	ticket, err := jiraClient.GetTicket(ticketID)
+	if err != nil {
+		return err
+	}
+
+	if ticket.Fields.Parent == nil {
+		fmt.Println(utils.Dim(fmt.Sprintf("  %s has no parent ticket", ticketID)))
+		return nil
+	}
+
+	fmt.Printf("  %s %s\n", utils.Bold("Parent:"), utils.BrightWhite(ticket.Fields.Parent.Key))
+	fmt.Printf("  %s %s\n", utils.Bold("Summary:"), utils.Dim(ticket.Fields.Parent.Fields.Summary))
+	return nil
+}
+
+func handleSync(ctx context.Context, dryRun bool, conflictMode string) error {
+	switch conflictMode {
+	case "jira-wins", "local-wins", "prompt":
+	default:
+		return fmt.Errorf("unknown --conflict mode %q (want jira-wins, local-wins, or prompt)", conflictMode)
+	}
+
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+
+	project := settings.Jira.ProjectKey
+	if project == "" {
+		return errors.New("no Jira project configured; set jira.project_key first")
+	}
+
+	store, err := cache.Open()
+	if err != nil {
+		return fmt.Errorf("open cache: %w", err)
+	}
+
+	jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
+
+	pendingStatus := map[string]string{}
+	for _, change := range store.PendingChanges() {
+		if change.Kind == cache.PendingStatus {
+			pendingStatus[change.TicketKey] = change.Value
+		}
+	}
+
+	importer := cache.NewJiraImporter(jiraClient, project)
+	since := store.Cursor(project)
+	watermark := since
+
+	logging.Info(fmt.Sprintf("Syncing %s since %s...", project, formatCursor(since)))
+	if dryRun {
+		logging.Debug("dry run: cache and Jira will not be changed")
+	}
+
+	// jiraWinsTickets collects tickets whose conflicting local status change
+	// lost to Jira's, so that change is dropped below instead of being
+	// exported and clobbering the Jira status we just decided to keep.
+	jiraWinsTickets := map[string]bool{}
+
+	count := 0
+	for event := range importer.Import(ctx, since) {
+		if event.Err != nil {
+			logging.Warn(event.Err.Error())
+			continue
+		}
+
+		remoteStatus := event.Ticket.Fields.Status.Name
+		if localStatus, conflicted := pendingStatus[event.Ticket.Key]; conflicted && localStatus != remoteStatus {
+			keepLocal, err := resolveSyncConflict(event.Ticket.Key, localStatus, remoteStatus, conflictMode)
+			if err != nil {
+				return err
+			}
+			if keepLocal {
+				logging.Warn("keeping local status over Jira's", logging.F("ticket", event.Ticket.Key), logging.F("local", localStatus), logging.F("jira", remoteStatus))
+				event.Ticket.Fields.Status.Name = localStatus
+			} else {
+				logging.Warn("Jira's status overrides local", logging.F("ticket", event.Ticket.Key), logging.F("jira", remoteStatus), logging.F("local", localStatus))
+				jiraWinsTickets[event.Ticket.Key] = true
+			}
+		}
+
+		if dryRun {
+			logging.Debug("would import", logging.F("ticket", event.Ticket.Key))
+		} else {
+			store.Upsert(event.Ticket, event.Comments)
+		}
+		if updated := event.Ticket.Fields.Updated.Time; updated.After(watermark) {
+			watermark = updated
+		}
+		count++
+	}
+
+	// A ticket whose conflict resolved in Jira's favor must not also have
+	// its queued local status change exported afterward, or that export
+	// would immediately clobber the Jira status we just decided to keep.
+	var pending []cache.PendingChange
+	for _, change := range store.PendingChanges() {
+		if change.Kind == cache.PendingStatus && jiraWinsTickets[change.TicketKey] {
+			if dryRun {
+				logging.Debug("would drop local status change in favor of Jira's", logging.F("ticket", change.TicketKey))
+			} else {
+				store.ClearPending(change)
+			}
+			continue
+		}
+		pending = append(pending, change)
+	}
+
+	if len(pending) > 0 {
+		if dryRun {
+			logging.Debug("would export pending changes", logging.F("count", len(pending)))
+		} else {
+			exporter := cache.NewJiraExporter(jiraClient)
+			for _, result := range exporter.Export(pending) {
+				if result.Err != nil {
+					logging.Warn("could not export change", logging.F("kind", result.Change.Kind), logging.F("ticket", result.Change.TicketKey), logging.F("error", result.Err))
+					continue
+				}
+				store.ClearPending(result.Change)
+			}
+		}
+	}
+
+	if dryRun {
+		logging.Notice(fmt.Sprintf("Would sync %d ticket(s)", count))
+		return nil
+	}
+
+	if !watermark.IsZero() {
+		store.SetCursor(project, watermark)
+	}
+	if err := store.Save(); err != nil {
+		return fmt.Errorf("save cache: %w", err)
+	}
+
+	logging.Notice(fmt.Sprintf("Synced %d ticket(s)", count))
+	return nil
+}
+
+// resolveSyncConflict decides, for a ticket whose status changed both
+// locally (queued for export) and in Jira since the last sync, which side
+// wins. It returns true if the local status should be kept.
+func resolveSyncConflict(ticketKey, localStatus, remoteStatus, conflictMode string) (bool, error) {
+	switch conflictMode {
+	case "local-wins":
+		return true, nil
+	case "prompt":
+		choice, err := prompt.PromptChoice(
+			fmt.Sprintf("%s changed on both sides (local: %q, Jira: %q) — keep which?", ticketKey, localStatus, remoteStatus),
+			[]string{"jira-wins", "local-wins"}, "jira-wins")
+		if err != nil {
+			return false, err
+		}
+		return choice == "local-wins", nil
+	default: // "jira-wins"
+		return false, nil
+	}
+}
+
+func formatCursor(t time.Time) string {
+	if t.IsZero() {
+		return "the beginning"
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+func handleDone(waitCI bool, ciTimeout time.Duration, reviewers []string) error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+
+	gitClient, err := git.NewClient()
+	if err != nil {
+		return err
+	}
+
+	clean, err := gitClient.IsClean()
+	if err != nil {
+		return err
+	}
+	if !clean {
+		return errors.New("uncommitted changes detected. Commit or stash before running 'devflow done'")
+	}
+
+	branch, err := gitClient.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	ticketID, err := utils.ExtractTicketID(branch)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(utils.Cyan(utils.Bold("Finalizing work...")))
+	fmt.Println()
+	fmt.Println(utils.Dim("  Pushing branch to remote..."))
+	if err := gitClient.Push(branch); err != nil {
+		return err
+	}
+
+	jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
+	fmt.Println(utils.Dim("  Fetching ticket information..."))
+	ticket, err := jiraClient.GetTicket(ticketID)
+	if err != nil {
+		return err
+	}
+
+	prTitle := fmt.Sprintf("%s: %s", ticketID, ticket.Fields.Summary)
+	prDescription := fmt.Sprintf("Resolves %s\n\nJira: %s/browse/%s", ticketID, settings.Jira.URL, ticketID)
+	if linked := jira.FormatIssueLinks(ticket.Fields.IssueLinks); linked != "" {
+		prDescription += fmt.Sprintf("\n\nLinked issues:\n%s", linked)
+	}
+
+	projectPath := filepath.Base(gitClient.Root())
+	provider, err := gitProviderFactory(settings.Git.Provider, gitProviderConfig(settings, projectPath))
+	if err != nil {
+		return err
+	}
+	if err := provider.Validate(); err != nil {
+		return err
+	}
+
+	if waitCI {
+		if err := waitForPipeline(provider, jiraClient, ticketID, branch, ciTimeout); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(utils.Dim(fmt.Sprintf("  Creating %s...", prNoun(provider.Name()))))
+	prURL, err := provider.CreatePullRequest(branch, "main", prTitle, prDescription)
+	if err != nil {
+		return err
+	}
+
+	if len(reviewers) > 0 {
+		if err := requestReviewers(provider, branch, reviewers); err != nil {
+			fmt.Println(utils.Yellow(fmt.Sprintf("  Could not request reviewers: %v", err)))
+		} else {
+			fmt.Println(utils.Dim(fmt.Sprintf("  Requested review from %s", strings.Join(reviewers, ", "))))
+		}
+	}
+
+	fmt.Println(utils.Dim("  Updating Jira status to 'In Review'..."))
+	applyStatusChangeThenPush(ticket, "In Review", jiraClient)
+
+	label := "PR"
+	if provider.Name() == "gitlab" {
+		label = "MR"
+	}
+
+	fmt.Println()
+	fmt.Println(utils.Green(utils.Bold("All done! Ready for review!")))
+	fmt.Printf("  %s %s\n", utils.Bold("Ticket:"), utils.BrightWhite(ticketID))
+	fmt.Printf("  %s %s\n", utils.Bold("Branch:"), utils.BrightWhite(branch))
+	fmt.Printf("  %s %s\n", utils.Bold(label+":"), utils.Cyan(prURL))
+
+	return nil
+}
+
+// applyStatusChangeThenPush updates ticket's status in the local cache
+// and queues it for export before attempting the Jira call, so list/
+// search reflect the change immediately even if the network call is slow
+// or fails; on success the queued change is cleared so a later `devflow
+// sync` doesn't resend it. Cache errors are reported but never block the
+// underlying Jira update.
+func applyStatusChangeThenPush(ticket *models.JiraTicket, transition string, jiraClient jiraService) {
+	store, err := cache.Open()
+	if err != nil {
+		fmt.Println(utils.Yellow(fmt.Sprintf("  Could not open local cache: %v", err)))
+		store = nil
+	}
+
+	var change cache.PendingChange
+	if store != nil {
+		comments := []models.JiraComment(nil)
+		if cached, ok := store.Get(ticket.Key); ok {
+			comments = cached.Comments
+		}
+		store.Upsert(*ticket, comments)
+		store.ApplyLocalStatus(ticket.Key, transition)
+		change = cache.PendingChange{TicketKey: ticket.Key, Kind: cache.PendingStatus, Value: transition, QueuedAt: time.Now()}
+		store.QueuePending(change)
+		if err := store.Save(); err != nil {
+			fmt.Println(utils.Yellow(fmt.Sprintf("  Could not save local cache: %v", err)))
+		}
+	}
+
+	if err := jiraClient.UpdateStatus(ticket.Key, transition); err != nil {
+		fmt.Println(utils.Yellow(fmt.Sprintf("  Could not update status: %v", err)))
+		return
+	}
+	fmt.Println(utils.Green(fmt.Sprintf("  ✓ Status updated to '%s'", transition)))
+
+	if store != nil {
+		store.ClearPending(change)
+		if err := store.Save(); err != nil {
+			fmt.Println(utils.Yellow(fmt.Sprintf("  Could not update local cache: %v", err)))
+		}
+	}
+}
+
+// requestReviewers finds the just-created PR/MR for branch among the
+// provider's open pull requests and requests review from the given
+// usernames. Providers don't expose a "look up by branch" endpoint, so
+// this scans ListOpenPullRequests rather than adding one more per-backend
+// method just for this.
+func requestReviewers(provider providers.Provider, branch string, reviewers []string) error {
+	open, err := provider.ListOpenPullRequests()
+	if err != nil {
+		return err
+	}
+	for _, pr := range open {
+		if pr.Source == branch {
+			return provider.AddReviewers(pr.ID, reviewers)
+		}
+	}
+	return fmt.Errorf("no open %s found for branch %s", prNoun(provider.Name()), branch)
+}
+
+// ciPollInterval is how often waitForPipeline re-checks CI status. The
+// underlying requests already retry transient failures via each
+// provider's httpx-backed transport, so this only governs how often we
+// ask "is it done yet".
+const ciPollInterval = 10 * time.Second
+
+// waitForPipeline blocks until the given branch's CI pipeline/check-suite
+// resolves, reporting progress as it polls. If the provider doesn't
+// implement providers.PipelineChecker, it's a no-op. On failure or
+// timeout, it posts a Jira comment recording the blocked state and
+// returns an error so the caller skips creating the PR/MR.
+func waitForPipeline(provider providers.Provider, jiraClient jiraService, ticketID, branch string, timeout time.Duration) error {
+	checker, ok := provider.(providers.PipelineChecker)
+	if !ok {
+		fmt.Println(utils.Yellow(fmt.Sprintf("  %s does not support --wait-ci; skipping", provider.Name())))
+		return nil
+	}
+
+	fmt.Println(utils.Dim(fmt.Sprintf("  Waiting for CI on %s (timeout %s)...", branch, timeout)))
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := checker.CheckPipelineStatus(branch)
+		if err != nil {
+			return err
+		}
+
+		switch status.State {
+		case "success":
+			fmt.Println(utils.Green("  ✓ CI passed"))
+			return nil
+		case "failed":
+			reason := fmt.Sprintf("CI failed on branch %s", branch)
+			if len(status.FailedJobs) > 0 {
+				reason = fmt.Sprintf("%s (failed: %s)", reason, strings.Join(status.FailedJobs, ", "))
+			}
+			if status.URL != "" {
+				reason = fmt.Sprintf("%s\n%s", reason, status.URL)
+			}
+			if commentErr := jiraClient.AddComment(ticketID, reason); commentErr != nil {
+				fmt.Println(utils.Yellow(fmt.Sprintf("  Could not post CI-failure comment: %v", commentErr)))
+			}
+			return fmt.Errorf("%s", reason)
+		}
+
+		if time.Now().After(deadline) {
+			reason := fmt.Sprintf("Timed out waiting %s for CI on branch %s", timeout, branch)
+			if commentErr := jiraClient.AddComment(ticketID, reason); commentErr != nil {
+				fmt.Println(utils.Yellow(fmt.Sprintf("  Could not post CI-timeout comment: %v", commentErr)))
+			}
+			return errors.New(reason)
+		}
+		time.Sleep(ciPollInterval)
+	}
+}
+
+func handleConfigShow() error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	printConfig(settings)
+	return nil
+}
+
+func handleConfigSet(key, value string) error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	if err := updateConfigValue(settings, key, value); err != nil {
+		return err
+	}
+	if err := settings.Save(); err != nil {
+		return err
+	}
+	fmt.Println(utils.Green(utils.Bold(fmt.Sprintf("✓ Updated %s to: %s", key, value))))
+	return nil
+}
+
+func handleConfigValidate() error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	fmt.Println(utils.Cyan(utils.Bold("Validating configuration...")))
+	fmt.Println()
+	fmt.Print(utils.Dim("  Testing Jira connection... "))
+	jiraClient := jiraFactory(settings.Jira.URL, settings.Jira.Email, settings.Jira.AuthMethod)
+	if err := jiraClient.TestConnection(); err != nil {
+		fmt.Println(utils.Red("✗"))
+		fmt.Println(utils.Yellow(fmt.Sprintf("  Jira validation failed: %v", err)))
+	} else {
+		fmt.Println(utils.Green("✓"))
+	}
+	if settings.Git.Token == "" {
+		fmt.Println(utils.Yellow("  Warning: Git token is empty"))
+	} else {
+		fmt.Println(utils.Dim("  Git token configured"))
+	}
+	return nil
+}
+
+func handleConfigPath() error {
+	path, err := config.ConfigPath()
+	if err != nil {
+		return err
+	}
+	fmt.Println(path)
+	return nil
+}
+
+func handleTestJira(ticketID, jiraURL, email, token string) error {
+	if ticketID == "" || jiraURL == "" || email == "" || token == "" {
 		return errors.New("ticket id, url, email, and token are required")
 	}
 
@@ -721,9 +1444,362 @@ func loadSettings() (*config.Settings, error) {
 		}
 		return nil, err
 	}
+
+	profileName := profileFlag
+	if profileName == "" {
+		profileName = os.Getenv("DEVFLOW_PROFILE")
+	}
+	if profileName == "" {
+		profileName = settings.ActiveProfile
+	}
+	if profileName != "" {
+		resolved, err := settings.ResolveProfile(profileName)
+		if err != nil {
+			return nil, err
+		}
+		settings = resolved
+	}
+
+	if err := resolveCredentialReferences(settings); err != nil {
+		return nil, err
+	}
 	return settings, nil
 }
 
+// resolveCredentialReferences swaps any "credential:target/userID"
+// placeholder left in config.toml's token fields for the actual secret
+// from the auth store, so config.toml itself never needs to hold one.
+func resolveCredentialReferences(settings *config.Settings) error {
+	if auth.IsReference(settings.Jira.AuthMethod.Token) {
+		token, err := auth.ResolveToken(settings.Jira.AuthMethod.Token)
+		if err != nil {
+			return fmt.Errorf("jira token: %w", err)
+		}
+		settings.Jira.AuthMethod.Token = token
+	}
+	if auth.IsReference(settings.Git.Token) {
+		token, err := auth.ResolveToken(settings.Git.Token)
+		if err != nil {
+			return fmt.Errorf("git token: %w", err)
+		}
+		settings.Git.Token = token
+	}
+	return nil
+}
+
+func handleProfileList() error {
+	raw, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if len(raw.Profiles) == 0 {
+		fmt.Println(utils.Dim("No profiles configured. Run 'devflow profile add <name>' to create one."))
+		return nil
+	}
+
+	fmt.Println(utils.Cyan(utils.Bold("Profiles")))
+	fmt.Println()
+	for _, name := range profileNamesSorted(raw.Profiles) {
+		marker := "  "
+		if name == raw.ActiveProfile {
+			marker = utils.Green("* ")
+		}
+		profile := raw.Profiles[name]
+		fmt.Printf("%s%s  %s\n", marker, utils.BrightWhite(name), utils.Dim(fmt.Sprintf("(%s, %s)", profile.Jira.URL, profile.Git.Provider)))
+	}
+	return nil
+}
+
+func handleProfileAdd(name string) error {
+	fmt.Println(utils.Cyan(utils.Bold(fmt.Sprintf("Adding profile %q", name))))
+	fmt.Println()
+
+	jiraURL, err := prompt.Prompt("Jira URL", prompt.Required, prompt.IsURL)
+	if err != nil {
+		return err
+	}
+	jiraEmail, err := prompt.Prompt("Jira email", prompt.Required, prompt.IsEmail)
+	if err != nil {
+		return err
+	}
+	projectKey, err := prompt.Prompt("Default project key", prompt.Required)
+	if err != nil {
+		return err
+	}
+	jiraToken, err := prompt.PromptPassword("Jira API token", prompt.Required)
+	if err != nil {
+		return err
+	}
+
+	jiraCred := &config.Credential{Target: "jira", Auth: config.AuthMethod{Type: "api_token", Token: jiraToken}}
+	if err := config.SaveCredential(jiraCred); err != nil {
+		return fmt.Errorf("save jira credential: %w", err)
+	}
+
+	gitProvider, err := prompt.PromptChoice("Git provider", providers.Names(), "gitlab")
+	if err != nil {
+		return err
+	}
+	gitBaseURL, err := prompt.Prompt("Git base URL", prompt.Required, prompt.IsURL)
+	if err != nil {
+		return err
+	}
+	gitOwner, _ := prompt.PromptDefault("Repository owner (blank if not applicable)", "")
+	gitRepo, _ := prompt.PromptDefault("Repository name (blank if not applicable)", "")
+	gitToken, err := prompt.PromptPassword("Git API token", prompt.Required)
+	if err != nil {
+		return err
+	}
+
+	gitCred := &config.Credential{Target: gitProvider, Auth: config.AuthMethod{Type: "token", Token: gitToken}}
+	if err := config.SaveCredential(gitCred); err != nil {
+		return fmt.Errorf("save git credential: %w", err)
+	}
+
+	settings, err := config.Load()
+	if err != nil && !errors.Is(err, config.ErrConfigNotFound) {
+		return err
+	}
+	if settings == nil {
+		settings = &config.Settings{}
+	}
+	if settings.Profiles == nil {
+		settings.Profiles = map[string]config.Profile{}
+	}
+
+	settings.Profiles[name] = config.Profile{
+		Jira: config.JiraRef{
+			URL:          strings.TrimSpace(jiraURL),
+			Email:        strings.TrimSpace(jiraEmail),
+			ProjectKey:   strings.TrimSpace(projectKey),
+			CredentialID: jiraCred.ID,
+		},
+		Git: config.GitRef{
+			Provider:     strings.ToLower(strings.TrimSpace(gitProvider)),
+			BaseURL:      strings.TrimSpace(gitBaseURL),
+			Owner:        strings.TrimSpace(gitOwner),
+			Repo:         strings.TrimSpace(gitRepo),
+			CredentialID: gitCred.ID,
+		},
+		Preferences: config.Preferences{
+			BranchPrefix:      "feat",
+			DefaultTransition: "In Progress",
+		},
+	}
+	if settings.ActiveProfile == "" {
+		settings.ActiveProfile = name
+	}
+
+	if err := settings.Save(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(utils.Green(utils.Bold(fmt.Sprintf("✓ Profile %q saved", name))))
+	return nil
+}
+
+func handleProfileUse(name string) error {
+	settings, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if _, ok := settings.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	settings.ActiveProfile = name
+	if err := settings.Save(); err != nil {
+		return err
+	}
+	fmt.Println(utils.Green(fmt.Sprintf("✓ Active profile set to %q", name)))
+	return nil
+}
+
+func handleProfileRemove(name string) error {
+	settings, err := config.Load()
+	if err != nil {
+		return err
+	}
+	profile, ok := settings.Profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+
+	delete(settings.Profiles, name)
+	if settings.ActiveProfile == name {
+		settings.ActiveProfile = ""
+	}
+	if err := settings.Save(); err != nil {
+		return err
+	}
+
+	if profile.Jira.CredentialID != "" {
+		_ = config.RemoveCredential(profile.Jira.CredentialID)
+	}
+	if profile.Git.CredentialID != "" {
+		_ = config.RemoveCredential(profile.Git.CredentialID)
+	}
+
+	fmt.Println(utils.Green(fmt.Sprintf("✓ Profile %q removed", name)))
+	return nil
+}
+
+func profileNamesSorted(profiles map[string]config.Profile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// handleAuthLogin prompts for an identity and stores it in the
+// target+userID credential store (see internal/auth), independent of the
+// profile system's single embedded credential per target.
+func handleAuthLogin(target string) error {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return errors.New("target is required")
+	}
+
+	userID, err := prompt.Prompt("User ID (email or username)", prompt.Required)
+	if err != nil {
+		return err
+	}
+
+	kind, err := prompt.PromptChoice("Credential kind", []string{"token", "login_password", "oauth1", "oauth2"}, "token")
+	if err != nil {
+		return err
+	}
+
+	var cred auth.Credential
+	switch auth.Kind(kind) {
+	case auth.KindToken:
+		token, err := prompt.PromptSecretWithConfirm("Token", prompt.Required)
+		if err != nil {
+			return err
+		}
+		cred = auth.NewTokenCredential(target, userID, token)
+	case auth.KindLoginPassword:
+		password, err := prompt.PromptSecretWithConfirm("Password", prompt.Required)
+		if err != nil {
+			return err
+		}
+		cred = auth.NewLoginPasswordCredential(target, userID, userID, password)
+	case auth.KindOAuth1:
+		consumerKey, err := prompt.Prompt("Consumer key", prompt.Required)
+		if err != nil {
+			return err
+		}
+		privateKeyPath, err := prompt.Prompt("Private key path", prompt.Required)
+		if err != nil {
+			return err
+		}
+		accessToken, err := prompt.PromptPassword("Access token", prompt.Required)
+		if err != nil {
+			return err
+		}
+		tokenSecret, err := prompt.PromptPassword("Token secret", prompt.Required)
+		if err != nil {
+			return err
+		}
+		cred = auth.NewOAuth1Credential(target, userID, consumerKey, privateKeyPath, accessToken, tokenSecret)
+	case auth.KindOAuth2:
+		accessToken, err := prompt.PromptPassword("Access token", prompt.Required)
+		if err != nil {
+			return err
+		}
+		refreshToken, _ := prompt.PromptDefault("Refresh token (blank if none)", "")
+		cred = auth.NewOAuth2Credential(target, userID, accessToken, refreshToken)
+	default:
+		return fmt.Errorf("unknown credential kind %q", kind)
+	}
+
+	if err := auth.Store(cred); err != nil {
+		return fmt.Errorf("store credential: %w", err)
+	}
+
+	ref := auth.Reference(target, userID)
+	fmt.Println(utils.Green(fmt.Sprintf("✓ Stored %s credential for %s/%s", kind, target, userID)))
+	if kind == string(auth.KindToken) || kind == string(auth.KindOAuth2) {
+		fmt.Println(utils.Dim(fmt.Sprintf("  Reference it from config.toml with: %s", ref)))
+	}
+	return nil
+}
+
+func handleAuthList() error {
+	identities, err := auth.List()
+	if err != nil {
+		return err
+	}
+	if len(identities) == 0 {
+		fmt.Println(utils.Dim("No identities stored. Run 'devflow auth login <target>' to add one."))
+		return nil
+	}
+
+	fmt.Println(utils.Cyan(utils.Bold("Identities")))
+	fmt.Println()
+	for _, id := range identities {
+		fmt.Printf("  %s  %s\n", utils.BrightWhite(id.Target+"/"+id.UserID), utils.Dim(fmt.Sprintf("(%s)", id.Kind)))
+	}
+	return nil
+}
+
+func handleAuthRemove(target, userID string) error {
+	if err := auth.Remove(target, userID); err != nil {
+		return err
+	}
+	fmt.Println(utils.Green(fmt.Sprintf("✓ Removed credential for %s/%s", target, userID)))
+	return nil
+}
+
+// handleAuthJiraOAuthSetup re-runs the OAuth 1.0a request-token/authorize/
+// access-token dance outside of `devflow init`, for re-authorizing a
+// self-hosted Jira application link whose access token expired or was
+// revoked, without walking the rest of the setup wizard again. The fresh
+// tokens are written to the credential store under jira/<user-id> and,
+// if a config.toml already exists, into its top-level [jira.auth_method]
+// section too, which is used whenever no profile is selected; its
+// [profiles.*] sections are kept as they are.
+func handleAuthJiraOAuthSetup() error {
+	settings, settingsErr := loadSettings()
+	jiraURL := ""
+	if settingsErr == nil {
+		jiraURL = settings.Jira.URL
+	}
+	jiraURL, err := prompt.PromptDefault("Jira URL", jiraURL, prompt.Required)
+	if err != nil {
+		return err
+	}
+
+	authMethod, err := runOAuth1Setup(jiraURL)
+	if err != nil {
+		return err
+	}
+
+	userID, err := prompt.PromptDefault("User ID (email or username)", "default", prompt.Required)
+	if err != nil {
+		return err
+	}
+
+	cred := auth.NewOAuth1Credential("jira", userID, authMethod.ConsumerKey, authMethod.PrivateKeyPath, authMethod.AccessToken, authMethod.TokenSecret)
+	if err := auth.Store(cred); err != nil {
+		return fmt.Errorf("store credential: %w", err)
+	}
+	fmt.Println(utils.Green(fmt.Sprintf("✓ Stored oauth1 credential for jira/%s", userID)))
+
+	if raw, err := config.Load(); err == nil {
+		raw.Jira.AuthMethod = authMethod
+		if err := raw.Save(); err != nil {
+			fmt.Println(utils.Yellow(fmt.Sprintf("  Stored in the credential store, but could not update config.toml: %v", err)))
+			return nil
+		}
+		fmt.Println(utils.Dim("  Updated config.toml's [jira.auth_method] with the new tokens"))
+	}
+	return nil
+}
+
 func printConfig(settings *config.Settings) {
 	fmt.Println(utils.Cyan(utils.Bold("Current Configuration")))
 	fmt.Println()
@@ -745,6 +1821,9 @@ func printConfig(settings *config.Settings) {
 	if settings.Git.Repo != "" {
 		fmt.Printf("  %s %s\n", utils.Dim("repo:"), utils.BrightWhite(settings.Git.Repo))
 	}
+	if settings.Git.APIVersion != "" {
+		fmt.Printf("  %s %s\n", utils.Dim("api_version:"), utils.BrightWhite(settings.Git.APIVersion))
+	}
 	fmt.Printf("  %s %s\n", utils.Dim("token:"), utils.Yellow(config.MaskToken(settings.Git.Token)))
 
 	fmt.Println()
@@ -788,6 +1867,8 @@ func updateConfigValue(settings *config.Settings, key, value string) error {
 			settings.Git.Owner = value
 		case "repo":
 			settings.Git.Repo = value
+		case "api_version":
+			settings.Git.APIVersion = value
 		default:
 			return fmt.Errorf("unknown git field: %s", field)
 		}
@@ -797,6 +1878,12 @@ func updateConfigValue(settings *config.Settings, key, value string) error {
 			settings.Preferences.BranchPrefix = value
 		case "default_transition":
 			settings.Preferences.DefaultTransition = value
+		case "cache_ttl_minutes":
+			minutes, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid cache_ttl_minutes: %w", err)
+			}
+			settings.Preferences.CacheTTLMinutes = minutes
 		default:
 			return fmt.Errorf("unknown preferences field: %s", field)
 		}
@@ -807,6 +1894,24 @@ func updateConfigValue(settings *config.Settings, key, value string) error {
 	return nil
 }
 
+func gitProviderConfig(settings *config.Settings, projectPath string) providers.Config {
+	return providers.Config{
+		BaseURL:     settings.Git.BaseURL,
+		Owner:       settings.Git.Owner,
+		Repo:        settings.Git.Repo,
+		Token:       settings.Git.Token,
+		APIVersion:  settings.Git.APIVersion,
+		ProjectPath: projectPath,
+	}
+}
+
+func prNoun(providerName string) string {
+	if providerName == "gitlab" {
+		return "merge request"
+	}
+	return "pull request"
+}
+
 func printTicketList(tickets []models.JiraTicket) {
 	for _, ticket := range tickets {
 		fmt.Printf("  %s [%s]  %s\n",
@@ -836,13 +1941,10 @@ func promptSelection(count int) (int, error) {
 	if count == 0 {
 		return -1, nil
 	}
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Print("Select a ticket number (or press Enter to cancel): ")
-	input, err := reader.ReadString('\n')
+	input, err := prompt.Prompt(fmt.Sprintf("Select a ticket number (1-%d, or press Enter to cancel)", count), prompt.InRange(1, count))
 	if err != nil {
 		return -1, err
 	}
-	input = strings.TrimSpace(input)
 	if input == "" {
 		return -1, nil
 	}
@@ -850,12 +1952,5 @@ func promptSelection(count int) (int, error) {
 	if err != nil {
 		return -1, fmt.Errorf("invalid selection: %s", input)
 	}
-	if idx < 1 || idx > count {
-		return -1, fmt.Errorf("selection out of range (1-%d)", count)
-	}
 	return idx - 1, nil
 }
-
-func escapeJQL(value string) string {
-	return strings.ReplaceAll(value, "\"", "\\\"")
-}
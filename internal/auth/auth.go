@@ -0,0 +1,173 @@
+// Package auth implements devflow's identity model: a Credential
+// interface modeled on git-bug's bridge/core/auth, covering plain
+// tokens, HTTP basic login/password pairs, Jira-style OAuth1, and
+// OAuth2 bearer tokens. Unlike config.Credential (which is addressed by
+// a generated ID and embedded in a single profile), values here are
+// addressed by target+userID so the same host can hold more than one
+// identity — see Store in store.go.
+package auth
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// Kind identifies which concrete Credential a stored record holds.
+type Kind string
+
+const (
+	KindToken         Kind = "token"
+	KindLoginPassword Kind = "login_password"
+	KindOAuth1        Kind = "oauth1"
+	KindOAuth2        Kind = "oauth2"
+)
+
+// Credential is a single identity capable of authorizing a request
+// against its target (e.g. "jira", "github", "gitlab.example.com").
+// The interface only identifies the credential; the token, login/password
+// and OAuth2 kinds each add a Header method returning their Authorization
+// header value, while OAuth1Credential carries raw signing material
+// instead, since its signature depends on each request.
+type Credential interface {
+	Kind() Kind
+	Target() string
+	UserID() string
+}
+
+type identity struct {
+	target string
+	userID string
+}
+
+func (i identity) Target() string { return i.target }
+func (i identity) UserID() string { return i.userID }
+
+// TokenCredential authorizes with a single bearer token, e.g. a GitHub
+// personal access token or a Jira personal access token.
+type TokenCredential struct {
+	identity
+	Token string
+}
+
+func NewTokenCredential(target, userID, token string) *TokenCredential {
+	return &TokenCredential{identity: identity{target: target, userID: userID}, Token: token}
+}
+
+func (c *TokenCredential) Kind() Kind { return KindToken }
+
+// Header returns the "Authorization" header value for this credential.
+func (c *TokenCredential) Header() string {
+	return "Bearer " + c.Token
+}
+
+// LoginPasswordCredential authorizes with HTTP Basic auth, e.g. a Jira
+// account email paired with an API token.
+type LoginPasswordCredential struct {
+	identity
+	Login    string
+	Password string
+}
+
+func NewLoginPasswordCredential(target, userID, login, password string) *LoginPasswordCredential {
+	return &LoginPasswordCredential{identity: identity{target: target, userID: userID}, Login: login, Password: password}
+}
+
+func (c *LoginPasswordCredential) Kind() Kind { return KindLoginPassword }
+
+// Header returns the "Authorization" header value for this credential.
+func (c *LoginPasswordCredential) Header() string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.Login+":"+c.Password))
+}
+
+// OAuth1Credential holds an OAuth 1.0a consumer key/RSA keypair plus the
+// exchanged access token, the flavor on-prem Jira uses via application
+// links. Signing is request-specific (it covers the method and URL), so
+// this type carries the raw material rather than a precomputed header;
+// jira.NewClientWithCredential builds a signer from it the same way
+// jira.NewClient already does from config.AuthMethod.
+type OAuth1Credential struct {
+	identity
+	ConsumerKey    string
+	PrivateKeyPath string
+	AccessToken    string
+	TokenSecret    string
+}
+
+func NewOAuth1Credential(target, userID, consumerKey, privateKeyPath, accessToken, tokenSecret string) *OAuth1Credential {
+	return &OAuth1Credential{
+		identity:       identity{target: target, userID: userID},
+		ConsumerKey:    consumerKey,
+		PrivateKeyPath: privateKeyPath,
+		AccessToken:    accessToken,
+		TokenSecret:    tokenSecret,
+	}
+}
+
+func (c *OAuth1Credential) Kind() Kind { return KindOAuth1 }
+
+// OAuth2Credential holds a bearer access token plus an optional refresh
+// token, for providers using plain OAuth2 (e.g. a GitHub App install).
+type OAuth2Credential struct {
+	identity
+	AccessToken  string
+	RefreshToken string
+}
+
+func NewOAuth2Credential(target, userID, accessToken, refreshToken string) *OAuth2Credential {
+	return &OAuth2Credential{identity: identity{target: target, userID: userID}, AccessToken: accessToken, RefreshToken: refreshToken}
+}
+
+func (c *OAuth2Credential) Kind() Kind { return KindOAuth2 }
+
+// Header returns the "Authorization" header value for this credential.
+func (c *OAuth2Credential) Header() string {
+	return "Bearer " + c.AccessToken
+}
+
+func decode(kind Kind, target, userID string, data rawFields) (Credential, error) {
+	switch kind {
+	case KindToken:
+		return NewTokenCredential(target, userID, data.Token), nil
+	case KindLoginPassword:
+		return NewLoginPasswordCredential(target, userID, data.Login, data.Password), nil
+	case KindOAuth1:
+		return NewOAuth1Credential(target, userID, data.ConsumerKey, data.PrivateKeyPath, data.AccessToken, data.TokenSecret), nil
+	case KindOAuth2:
+		return NewOAuth2Credential(target, userID, data.AccessToken, data.RefreshToken), nil
+	default:
+		return nil, fmt.Errorf("unknown credential kind %q", kind)
+	}
+}
+
+// rawFields is the union of every concrete credential's fields, used so
+// store.go can marshal/unmarshal any kind through one struct.
+type rawFields struct {
+	Token          string `json:"token,omitempty"`
+	Login          string `json:"login,omitempty"`
+	Password       string `json:"password,omitempty"`
+	ConsumerKey    string `json:"consumer_key,omitempty"`
+	PrivateKeyPath string `json:"private_key_path,omitempty"`
+	AccessToken    string `json:"access_token,omitempty"`
+	TokenSecret    string `json:"token_secret,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+}
+
+func toRawFields(c Credential) rawFields {
+	switch cred := c.(type) {
+	case *TokenCredential:
+		return rawFields{Token: cred.Token}
+	case *LoginPasswordCredential:
+		return rawFields{Login: cred.Login, Password: cred.Password}
+	case *OAuth1Credential:
+		return rawFields{
+			ConsumerKey:    cred.ConsumerKey,
+			PrivateKeyPath: cred.PrivateKeyPath,
+			AccessToken:    cred.AccessToken,
+			TokenSecret:    cred.TokenSecret,
+		}
+	case *OAuth2Credential:
+		return rawFields{AccessToken: cred.AccessToken, RefreshToken: cred.RefreshToken}
+	default:
+		return rawFields{}
+	}
+}
@@ -0,0 +1,53 @@
+package auth
+
+import "testing"
+
+func TestTokenCredentialHeader(t *testing.T) {
+	c := NewTokenCredential("github", "me", "abc123")
+	if got, want := c.Header(), "Bearer abc123"; got != want {
+		t.Fatalf("Header() = %q, want %q", got, want)
+	}
+	if c.Kind() != KindToken {
+		t.Fatalf("Kind() = %q, want %q", c.Kind(), KindToken)
+	}
+}
+
+func TestLoginPasswordCredentialHeader(t *testing.T) {
+	c := NewLoginPasswordCredential("jira", "dev@example.com", "dev@example.com", "secret")
+	if got, want := c.Header(), "Basic ZGV2QGV4YW1wbGUuY29tOnNlY3JldA=="; got != want {
+		t.Fatalf("Header() = %q, want %q", got, want)
+	}
+}
+
+func TestOAuth2CredentialHeader(t *testing.T) {
+	c := NewOAuth2Credential("github", "me", "tok", "refresh")
+	if got, want := c.Header(), "Bearer tok"; got != want {
+		t.Fatalf("Header() = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	cases := []Credential{
+		NewTokenCredential("github", "me", "tok"),
+		NewLoginPasswordCredential("jira", "me", "login", "pw"),
+		NewOAuth1Credential("jira", "me", "consumer", "/path/key.pem", "access", "secret"),
+		NewOAuth2Credential("github", "me", "access", "refresh"),
+	}
+
+	for _, want := range cases {
+		fields := toRawFields(want)
+		got, err := decode(want.Kind(), want.Target(), want.UserID(), fields)
+		if err != nil {
+			t.Fatalf("decode(%s): %v", want.Kind(), err)
+		}
+		if got.Target() != want.Target() || got.UserID() != want.UserID() || got.Kind() != want.Kind() {
+			t.Fatalf("decode(%s) = %+v, want %+v", want.Kind(), got, want)
+		}
+	}
+}
+
+func TestDecodeUnknownKind(t *testing.T) {
+	if _, err := decode(Kind("bogus"), "t", "u", rawFields{}); err == nil {
+		t.Fatalf("expected error for unknown kind")
+	}
+}
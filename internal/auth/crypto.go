@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Ilia01/devflow/internal/config"
+)
+
+// keyFileName holds the AES-256 key used to encrypt the JSON fallback
+// store when the OS keyring is unavailable (e.g. headless CI). It never
+// leaves the machine, so losing it just means the fallback entries need
+// to be re-stored via `devflow auth login`.
+const keyFileName = "credentials.key"
+
+func keyFilePath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, keyFileName), nil
+}
+
+// loadOrCreateKey returns the fallback store's AES-256 key, generating
+// and persisting one on first use.
+func loadOrCreateKey() ([]byte, error) {
+	path, err := keyFilePath()
+	if err != nil {
+		return nil, err
+	}
+	if key, err := os.ReadFile(path); err == nil {
+		if len(key) != 32 {
+			return nil, fmt.Errorf("credentials key at %s is malformed", path)
+		}
+		return key, nil
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read credentials key: %w", err)
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("generate credentials key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create config dir: %w", err)
+	}
+	if err := os.WriteFile(path, key, 0o600); err != nil {
+		return nil, fmt.Errorf("write credentials key: %w", err)
+	}
+	return key, nil
+}
+
+// encryptFallback AES-GCM encrypts secret under the fallback store's key,
+// returning base64(nonce || ciphertext).
+func encryptFallback(secret []byte) (string, error) {
+	key, err := loadOrCreateKey()
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	ciphertext := gcm.Seal(nonce, nonce, secret, nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// decryptFallback reverses encryptFallback.
+func decryptFallback(encoded string) ([]byte, error) {
+	key, err := loadOrCreateKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode encrypted credential: %w", err)
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted credential is truncated")
+	}
+	nonce, data := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credential: %w", err)
+	}
+	return plaintext, nil
+}
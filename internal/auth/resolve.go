@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// credentialRefPrefix marks a config.toml token field as an opaque
+// pointer into the credential store (e.g. "credential:jira/default")
+// rather than a literal secret, so `devflow auth login` output can be
+// pasted straight into config.toml without ever writing plaintext there.
+const credentialRefPrefix = "credential:"
+
+// IsReference reports whether value is a "credential:target/userID"
+// pointer rather than a literal secret.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, credentialRefPrefix)
+}
+
+// Reference formats the credential store pointer for target/userID, for
+// callers building the string to put in config.toml.
+func Reference(target, userID string) string {
+	return credentialRefPrefix + target + "/" + userID
+}
+
+// ResolveToken returns value unchanged unless it's a credential
+// reference, in which case it looks up the stored credential and
+// returns the bearer token/access token it carries. Non-token
+// credentials (login/password, OAuth1) aren't resolvable this way since
+// they don't reduce to a single string.
+func ResolveToken(value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+	target, userID, ok := strings.Cut(strings.TrimPrefix(value, credentialRefPrefix), "/")
+	if !ok {
+		return "", fmt.Errorf("malformed credential reference %q, want credential:target/userID", value)
+	}
+
+	cred, err := Get(target, userID)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", value, err)
+	}
+	switch c := cred.(type) {
+	case *TokenCredential:
+		return c.Token, nil
+	case *OAuth2Credential:
+		return c.AccessToken, nil
+	default:
+		return "", fmt.Errorf("credential %q is a %s, not a single-token credential", value, cred.Kind())
+	}
+}
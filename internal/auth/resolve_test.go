@@ -0,0 +1,47 @@
+package auth
+
+import "testing"
+
+func TestResolveTokenPassesThroughLiteralValues(t *testing.T) {
+	token, err := ResolveToken("plain-token-value")
+	if err != nil {
+		t.Fatalf("ResolveToken: %v", err)
+	}
+	if token != "plain-token-value" {
+		t.Fatalf("token = %q, want unchanged literal", token)
+	}
+}
+
+func TestResolveTokenLooksUpReference(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := Store(NewTokenCredential("jira", "default", "secret-abc")); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	token, err := ResolveToken(Reference("jira", "default"))
+	if err != nil {
+		t.Fatalf("ResolveToken: %v", err)
+	}
+	if token != "secret-abc" {
+		t.Fatalf("token = %q, want %q", token, "secret-abc")
+	}
+}
+
+func TestResolveTokenRejectsNonTokenCredential(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := Store(NewLoginPasswordCredential("jira", "default", "dev@example.com", "pw")); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	if _, err := ResolveToken(Reference("jira", "default")); err == nil {
+		t.Fatalf("expected error resolving a login/password credential as a token")
+	}
+}
+
+func TestResolveTokenMalformedReference(t *testing.T) {
+	if _, err := ResolveToken("credential:no-slash"); err == nil {
+		t.Fatalf("expected error for malformed reference")
+	}
+}
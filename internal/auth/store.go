@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/zalando/go-keyring"
+
+	"github.com/Ilia01/devflow/internal/config"
+)
+
+// keyringService is the go-keyring service name devflow stores
+// credentials under; the account name is target+"/"+userID.
+const keyringService = "devflow"
+
+// record is what's written to the JSON index on disk. When the keyring
+// accepted the secret, Encrypted is left empty and Get reads the secret
+// back from there instead; when no keyring is available (e.g. headless
+// CI), Encrypted carries the AES-GCM-encrypted secret (see crypto.go) so
+// the index doubles as an on-disk fallback store without ever holding
+// plaintext credentials.
+type record struct {
+	Target    string `json:"target"`
+	UserID    string `json:"user_id"`
+	Kind      Kind   `json:"kind"`
+	Encrypted string `json:"encrypted,omitempty"`
+}
+
+// Identity is the public, secret-free summary returned by List.
+type Identity struct {
+	Target string
+	UserID string
+	Kind   Kind
+}
+
+func key(target, userID string) string {
+	return target + "/" + userID
+}
+
+func storePath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "credentials.json"), nil
+}
+
+func loadIndex() (map[string]record, error) {
+	path, err := storePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]record{}, nil
+		}
+		return nil, fmt.Errorf("read auth store: %w", err)
+	}
+	index := map[string]record{}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("parse auth store: %w", err)
+	}
+	return index, nil
+}
+
+func saveIndex(index map[string]record) error {
+	path, err := storePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode auth store: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Store persists c under target+userID, preferring the OS keyring and
+// falling back to an AES-GCM-encrypted entry in the JSON index when no
+// keyring is available.
+func Store(c Credential) error {
+	k := key(c.Target(), c.UserID())
+	rec := record{Target: c.Target(), UserID: c.UserID(), Kind: c.Kind()}
+
+	fields := toRawFields(c)
+	secret, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("encode credential: %w", err)
+	}
+	if err := keyring.Set(keyringService, k, string(secret)); err != nil {
+		encrypted, err := encryptFallback(secret)
+		if err != nil {
+			return fmt.Errorf("encrypt fallback credential: %w", err)
+		}
+		rec.Encrypted = encrypted
+	}
+
+	index, err := loadIndex()
+	if err != nil {
+		return err
+	}
+	index[k] = rec
+	return saveIndex(index)
+}
+
+// Get loads the credential stored for target+userID, reading the secret
+// from the keyring or, failing that, decrypting the fallback entry.
+func Get(target, userID string) (Credential, error) {
+	k := key(target, userID)
+	index, err := loadIndex()
+	if err != nil {
+		return nil, err
+	}
+	rec, ok := index[k]
+	if !ok {
+		return nil, fmt.Errorf("no credential stored for %s", k)
+	}
+
+	var secret []byte
+	if rec.Encrypted != "" {
+		secret, err = decryptFallback(rec.Encrypted)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		plaintext, err := keyring.Get(keyringService, k)
+		if err != nil {
+			return nil, fmt.Errorf("read credential from keyring: %w", err)
+		}
+		secret = []byte(plaintext)
+	}
+
+	var fields rawFields
+	if err := json.Unmarshal(secret, &fields); err != nil {
+		return nil, fmt.Errorf("parse credential secret: %w", err)
+	}
+	return decode(rec.Kind, rec.Target, rec.UserID, fields)
+}
+
+// Remove deletes the credential stored for target+userID from both the
+// keyring and the JSON index. It is not an error if the keyring never
+// held it (e.g. it was only ever in the JSON fallback).
+func Remove(target, userID string) error {
+	k := key(target, userID)
+
+	index, err := loadIndex()
+	if err != nil {
+		return err
+	}
+	if _, ok := index[k]; !ok {
+		return fmt.Errorf("no credential stored for %s", k)
+	}
+	delete(index, k)
+
+	_ = keyring.Delete(keyringService, k)
+	return saveIndex(index)
+}
+
+// List returns every stored credential's target, userID and kind,
+// without touching the keyring for the secret material.
+func List() ([]Identity, error) {
+	index, err := loadIndex()
+	if err != nil {
+		return nil, err
+	}
+	identities := make([]Identity, 0, len(index))
+	for _, rec := range index {
+		identities = append(identities, Identity{Target: rec.Target, UserID: rec.UserID, Kind: rec.Kind})
+	}
+	return identities, nil
+}
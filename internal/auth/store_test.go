@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestStoreGetRemoveRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cred := NewTokenCredential("github", "dev@example.com", "tok-123")
+	if err := Store(cred); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	got, err := Get("github", "dev@example.com")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	token, ok := got.(*TokenCredential)
+	if !ok {
+		t.Fatalf("Get returned %T, want *TokenCredential", got)
+	}
+	if token.Token != "tok-123" {
+		t.Fatalf("Token = %q, want %q", token.Token, "tok-123")
+	}
+
+	identities, err := List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(identities) != 1 || identities[0].Target != "github" || identities[0].UserID != "dev@example.com" {
+		t.Fatalf("List() = %+v", identities)
+	}
+
+	if err := Remove("github", "dev@example.com"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := Get("github", "dev@example.com"); err == nil {
+		t.Fatalf("expected error after Remove")
+	}
+}
+
+func TestGetMissing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := Get("github", "nobody"); err == nil {
+		t.Fatalf("expected error for missing credential")
+	}
+}
+
+// TestFallbackIndexNeverHoldsPlaintext guards against the index file
+// regressing to the plaintext-JSON fallback this replaced: whatever ends
+// up on disk, the literal secret must not appear in it.
+func TestFallbackIndexNeverHoldsPlaintext(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := Store(NewTokenCredential("github", "dev@example.com", "super-secret-token")); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	path, err := storePath()
+	if err != nil {
+		t.Fatalf("storePath: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if strings.Contains(string(data), "super-secret-token") {
+		t.Fatalf("credentials.json contains the plaintext secret: %s", data)
+	}
+}
+
+func TestRemoveMissing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := Remove("github", "nobody"); err == nil {
+		t.Fatalf("expected error removing missing credential")
+	}
+}
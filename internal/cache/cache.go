@@ -0,0 +1,237 @@
+// Package cache mirrors a subset of Jira tickets to a local JSON store so
+// devflow can serve list/search/status without a network round-trip.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Ilia01/devflow/internal/config"
+	"github.com/Ilia01/devflow/internal/models"
+)
+
+// DefaultTTL is used when Preferences.CacheTTLMinutes is unset.
+const DefaultTTL = 15 * time.Minute
+
+type CachedTicket struct {
+	Ticket      models.JiraTicket
+	Comments    []models.JiraComment
+	Transitions []string
+	SyncedAt    time.Time
+}
+
+type onDiskCache struct {
+	Tickets map[string]CachedTicket `json:"tickets"`
+	Cursors map[string]time.Time    `json:"cursors"`
+	Pending []PendingChange         `json:"pending"`
+}
+
+// PendingKind identifies what sort of locally-recorded change a
+// PendingChange carries.
+type PendingKind string
+
+const (
+	PendingStatus  PendingKind = "status"
+	PendingComment PendingKind = "comment"
+)
+
+// PendingChange is a locally-recorded mutation waiting to be pushed to
+// Jira by a JiraExporter. handleStart/handleDone queue one of these (and
+// update the cached ticket in place) before making the network call, so
+// `list`/`search` reflect the change immediately even if the call is slow
+// or offline; `devflow sync` drains the queue via Export.
+type PendingChange struct {
+	TicketKey string
+	Kind      PendingKind
+	Value     string
+	QueuedAt  time.Time
+}
+
+// Store is a small JSON-backed mirror of Jira tickets. It is safe for
+// concurrent use within a single process; concurrent processes should still
+// avoid racing `devflow sync` invocations.
+type Store struct {
+	path string
+	mu   sync.Mutex
+	data onDiskCache
+}
+
+func Dir() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache"), nil
+}
+
+func path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tickets.json"), nil
+}
+
+// Open loads the on-disk cache, creating an empty one if it doesn't exist yet.
+func Open() (*Store, error) {
+	p, err := path()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{
+		path: p,
+		data: onDiskCache{
+			Tickets: map[string]CachedTicket{},
+			Cursors: map[string]time.Time{},
+			Pending: []PendingChange{},
+		},
+	}
+
+	raw, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("read cache: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, &store.data); err != nil {
+		return nil, fmt.Errorf("parse cache: %w", err)
+	}
+	return store, nil
+}
+
+func (s *Store) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cache: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("write cache: %w", err)
+	}
+	return nil
+}
+
+// Upsert stores or replaces a ticket and its comments in the cache.
+func (s *Store) Upsert(ticket models.JiraTicket, comments []models.JiraComment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Tickets[ticket.Key] = CachedTicket{
+		Ticket:   ticket,
+		Comments: comments,
+		SyncedAt: time.Now(),
+	}
+}
+
+func (s *Store) Get(key string) (CachedTicket, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cached, ok := s.data.Tickets[key]
+	return cached, ok
+}
+
+// List returns cached tickets for a project, optionally filtered by status.
+func (s *Store) List(projectKey, status string) []models.JiraTicket {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tickets []models.JiraTicket
+	for _, cached := range s.data.Tickets {
+		if projectKey != "" && !hasProjectPrefix(cached.Ticket.Key, projectKey) {
+			continue
+		}
+		if status != "" && cached.Ticket.Fields.Status.Name != status {
+			continue
+		}
+		tickets = append(tickets, cached.Ticket)
+	}
+	return tickets
+}
+
+func hasProjectPrefix(key, projectKey string) bool {
+	prefix := projectKey + "-"
+	return len(key) > len(prefix) && key[:len(prefix)] == prefix
+}
+
+// Cursor returns the high-water mark recorded for a project's last sync.
+func (s *Store) Cursor(projectKey string) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.Cursors[projectKey]
+}
+
+func (s *Store) SetCursor(projectKey string, t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Cursors[projectKey] = t
+}
+
+// IsFresh reports whether the project's sync cursor lies within ttl. The
+// cursor is the newest `updated` time seen by a sync, not the time that
+// sync ran, so a sync that imports no recently updated ticket does not make
+// the cache fresh.
+func (s *Store) IsFresh(projectKey string, ttl time.Duration) bool {
+	cursor := s.Cursor(projectKey)
+	if cursor.IsZero() {
+		return false
+	}
+	return time.Since(cursor) < ttl
+}
+
+// ApplyLocalStatus updates a cached ticket's status in place, without
+// touching its SyncedAt watermark, so offline reads reflect a change
+// that's queued for export but hasn't reached Jira yet. It's a no-op if
+// the ticket isn't cached.
+func (s *Store) ApplyLocalStatus(ticketKey, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cached, ok := s.data.Tickets[ticketKey]
+	if !ok {
+		return
+	}
+	cached.Ticket.Fields.Status.Name = status
+	s.data.Tickets[ticketKey] = cached
+}
+
+// QueuePending appends a locally-recorded change to be pushed by the next
+// Export.
+func (s *Store) QueuePending(change PendingChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Pending = append(s.data.Pending, change)
+}
+
+// PendingChanges returns a copy of the queued changes awaiting export.
+func (s *Store) PendingChanges() []PendingChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]PendingChange, len(s.data.Pending))
+	copy(out, s.data.Pending)
+	return out
+}
+
+// ClearPending removes the first queued change matching change from the
+// pending queue, typically once it has been exported successfully.
+func (s *Store) ClearPending(change PendingChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, p := range s.data.Pending {
+		if p == change {
+			s.data.Pending = append(s.data.Pending[:i], s.data.Pending[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/Ilia01/devflow/internal/models"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	store, err := Open()
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	return store
+}
+
+func TestApplyLocalStatusUpdatesCachedTicket(t *testing.T) {
+	store := newTestStore(t)
+	store.Upsert(models.JiraTicket{Key: "TEST-1", Fields: models.TicketFields{Status: models.TicketStatus{Name: "To Do"}}}, nil)
+
+	store.ApplyLocalStatus("TEST-1", "In Progress")
+
+	cached, ok := store.Get("TEST-1")
+	if !ok || cached.Ticket.Fields.Status.Name != "In Progress" {
+		t.Fatalf("expected local status update, got %+v", cached)
+	}
+}
+
+func TestApplyLocalStatusIgnoresUnknownTicket(t *testing.T) {
+	store := newTestStore(t)
+	store.ApplyLocalStatus("MISSING-1", "In Progress")
+	if _, ok := store.Get("MISSING-1"); ok {
+		t.Fatalf("expected no ticket to be created")
+	}
+}
+
+func TestQueuePendingAndClear(t *testing.T) {
+	store := newTestStore(t)
+	change := PendingChange{TicketKey: "TEST-1", Kind: PendingStatus, Value: "In Progress"}
+	store.QueuePending(change)
+
+	pending := store.PendingChanges()
+	if len(pending) != 1 || pending[0] != change {
+		t.Fatalf("expected queued change, got %+v", pending)
+	}
+
+	store.ClearPending(change)
+	if len(store.PendingChanges()) != 0 {
+		t.Fatalf("expected pending queue to be empty after clear")
+	}
+}
@@ -0,0 +1,48 @@
+package cache
+
+import "fmt"
+
+// exportTarget is the subset of jiraService Export needs to push queued
+// changes back to Jira.
+type exportTarget interface {
+	UpdateStatus(ticketID, transitionName string) error
+	AddComment(ticketID, body string) error
+}
+
+// ExportResult pairs a queued change with the outcome of pushing it.
+type ExportResult struct {
+	Change PendingChange
+	Err    error
+}
+
+// Exporter pushes locally-queued changes to Jira. Providers other than
+// Jira could plug in by implementing Exporter, mirroring Importer.
+type Exporter interface {
+	Export(changes []PendingChange) []ExportResult
+}
+
+// JiraExporter pushes queued status changes and comments to Jira.
+type JiraExporter struct {
+	Client exportTarget
+}
+
+func NewJiraExporter(client exportTarget) *JiraExporter {
+	return &JiraExporter{Client: client}
+}
+
+func (e *JiraExporter) Export(changes []PendingChange) []ExportResult {
+	results := make([]ExportResult, len(changes))
+	for i, change := range changes {
+		var err error
+		switch change.Kind {
+		case PendingStatus:
+			err = e.Client.UpdateStatus(change.TicketKey, change.Value)
+		case PendingComment:
+			err = e.Client.AddComment(change.TicketKey, change.Value)
+		default:
+			err = fmt.Errorf("unknown pending change kind %q", change.Kind)
+		}
+		results[i] = ExportResult{Change: change, Err: err}
+	}
+	return results
+}
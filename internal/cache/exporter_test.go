@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+)
+
+var errFakeExport = errors.New("export failed")
+
+type fakeExportTarget struct {
+	statuses map[string]string
+	comments map[string][]string
+	failKey  string
+}
+
+func (f *fakeExportTarget) UpdateStatus(ticketID, transitionName string) error {
+	if ticketID == f.failKey {
+		return errFakeExport
+	}
+	if f.statuses == nil {
+		f.statuses = map[string]string{}
+	}
+	f.statuses[ticketID] = transitionName
+	return nil
+}
+
+func (f *fakeExportTarget) AddComment(ticketID, body string) error {
+	if f.comments == nil {
+		f.comments = map[string][]string{}
+	}
+	f.comments[ticketID] = append(f.comments[ticketID], body)
+	return nil
+}
+
+func TestJiraExporterPushesStatusAndComment(t *testing.T) {
+	target := &fakeExportTarget{}
+	exporter := NewJiraExporter(target)
+
+	changes := []PendingChange{
+		{TicketKey: "TEST-1", Kind: PendingStatus, Value: "In Progress"},
+		{TicketKey: "TEST-1", Kind: PendingComment, Value: "started work"},
+	}
+	results := exporter.Export(changes)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Fatalf("unexpected error for %+v: %v", r.Change, r.Err)
+		}
+	}
+	if target.statuses["TEST-1"] != "In Progress" {
+		t.Fatalf("status not pushed: %+v", target.statuses)
+	}
+	if len(target.comments["TEST-1"]) != 1 || target.comments["TEST-1"][0] != "started work" {
+		t.Fatalf("comment not pushed: %+v", target.comments)
+	}
+}
+
+func TestJiraExporterReportsPerChangeError(t *testing.T) {
+	target := &fakeExportTarget{failKey: "TEST-2"}
+	exporter := NewJiraExporter(target)
+
+	results := exporter.Export([]PendingChange{{TicketKey: "TEST-2", Kind: PendingStatus, Value: "Done"}})
+	if len(results) != 1 || results[0].Err == nil {
+		t.Fatalf("expected an error result, got %+v", results)
+	}
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Ilia01/devflow/internal/jira"
+	"github.com/Ilia01/devflow/internal/models"
+)
+
+// ImportEvent is emitted by an Importer for each ticket it pulls in during a
+// sync. Providers other than Jira (GitLab issues, GitHub issues) can plug in
+// by implementing Importer and producing the same event shape.
+type ImportEvent struct {
+	Ticket   models.JiraTicket
+	Comments []models.JiraComment
+	Err      error
+}
+
+// Importer pulls tickets updated since a given watermark. Implementations
+// should close the returned channel once the import completes. ctx lets a
+// caller cancel a long-running import (e.g. a `devflow sync` against a
+// project with many tickets) without waiting for it to drain.
+type Importer interface {
+	Import(ctx context.Context, since time.Time) <-chan ImportEvent
+}
+
+// jiraSource is the interface Jira clients satisfy for importing; it mirrors
+// the subset of jiraService used elsewhere in the app.
+type jiraSource interface {
+	SearchWithJQLPage(ctx context.Context, jql string, startAt, maxResults int, opts ...jira.FetchOptions) (jira.SearchPage, error)
+	GetComments(ticketID string) ([]models.JiraComment, error)
+}
+
+// JiraImporter imports tickets from a single Jira project using an
+// `updated >= <since>` JQL cursor, paging fully through SearchWithJQLPage
+// (not just its first page) so a project with more tickets updated in the
+// window than PageSize isn't silently truncated. The query orders by
+// `updated ASC`, so if an import is interrupted partway, the caller's
+// watermark (the highest `updated` seen so far) still only covers tickets
+// it actually imported, making the next sync resumable rather than lossy.
+type JiraImporter struct {
+	Client     jiraSource
+	ProjectKey string
+	PageSize   int
+}
+
+func NewJiraImporter(client jiraSource, projectKey string) *JiraImporter {
+	return &JiraImporter{Client: client, ProjectKey: projectKey, PageSize: 50}
+}
+
+func (i *JiraImporter) Import(ctx context.Context, since time.Time) <-chan ImportEvent {
+	events := make(chan ImportEvent)
+
+	go func() {
+		defer close(events)
+
+		jql := fmt.Sprintf("project = %s", i.ProjectKey)
+		if !since.IsZero() {
+			jql = fmt.Sprintf("%s AND updated >= \"%s\"", jql, since.UTC().Format("2006-01-02 15:04"))
+		}
+		jql += " ORDER BY updated ASC"
+
+		pageSize := i.PageSize
+		if pageSize <= 0 {
+			pageSize = 50
+		}
+
+		startAt := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				events <- ImportEvent{Err: err}
+				return
+			}
+
+			page, err := i.Client.SearchWithJQLPage(ctx, jql, startAt, pageSize)
+			if err != nil {
+				events <- ImportEvent{Err: fmt.Errorf("search jira for sync: %w", err)}
+				return
+			}
+
+			for _, ticket := range page.Issues {
+				if err := ctx.Err(); err != nil {
+					events <- ImportEvent{Err: err}
+					return
+				}
+
+				comments, err := i.Client.GetComments(ticket.Key)
+				if err != nil {
+					events <- ImportEvent{Err: fmt.Errorf("fetch comments for %s: %w", ticket.Key, err)}
+					continue
+				}
+				events <- ImportEvent{Ticket: ticket, Comments: comments}
+			}
+
+			startAt += len(page.Issues)
+			if len(page.Issues) == 0 || startAt >= page.Total {
+				return
+			}
+		}
+	}()
+
+	return events
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ilia01/devflow/internal/jira"
+	"github.com/Ilia01/devflow/internal/models"
+)
+
+type fakeImportSource struct {
+	pages     [][]models.JiraTicket
+	total     int
+	pageCalls int
+}
+
+func (f *fakeImportSource) SearchWithJQLPage(ctx context.Context, jql string, startAt, maxResults int, opts ...jira.FetchOptions) (jira.SearchPage, error) {
+	index := startAt / maxResults
+	if index >= len(f.pages) {
+		return jira.SearchPage{StartAt: startAt, Total: f.total}, nil
+	}
+	f.pageCalls++
+	return jira.SearchPage{Issues: f.pages[index], StartAt: startAt, Total: f.total}, nil
+}
+
+func (f *fakeImportSource) GetComments(ticketID string) ([]models.JiraComment, error) {
+	return nil, nil
+}
+
+func TestJiraImporterPagesThroughAllResults(t *testing.T) {
+	source := &fakeImportSource{
+		pages: [][]models.JiraTicket{
+			{{Key: "TEST-1"}, {Key: "TEST-2"}},
+			{{Key: "TEST-3"}},
+		},
+		total: 3,
+	}
+	importer := &JiraImporter{Client: source, ProjectKey: "TEST", PageSize: 2}
+
+	var keys []string
+	for event := range importer.Import(context.Background(), time.Time{}) {
+		if event.Err != nil {
+			t.Fatalf("unexpected error: %v", event.Err)
+		}
+		keys = append(keys, event.Ticket.Key)
+	}
+
+	if len(keys) != 3 || keys[0] != "TEST-1" || keys[1] != "TEST-2" || keys[2] != "TEST-3" {
+		t.Fatalf("unexpected tickets: %v", keys)
+	}
+	if source.pageCalls != 2 {
+		t.Fatalf("expected 2 page fetches, got %d", source.pageCalls)
+	}
+}
+
+func TestJiraImporterStopsOnCancelledContext(t *testing.T) {
+	source := &fakeImportSource{
+		pages: [][]models.JiraTicket{{{Key: "TEST-1"}, {Key: "TEST-2"}}},
+		total: 2,
+	}
+	importer := &JiraImporter{Client: source, ProjectKey: "TEST", PageSize: 2}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var gotErr bool
+	for event := range importer.Import(ctx, time.Time{}) {
+		if event.Err != nil {
+			gotErr = true
+		}
+	}
+	if !gotErr {
+		t.Fatal("expected a cancellation error event")
+	}
+	if source.pageCalls != 0 {
+		t.Fatalf("expected no page fetches after cancellation, got %d", source.pageCalls)
+	}
+}
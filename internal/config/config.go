@@ -1,52 +1,95 @@
 package config
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"strings"
+
+	"github.com/pelletier/go-toml/v2"
 )
 
 var ErrConfigNotFound = errors.New("configuration not found")
 
 type Settings struct {
-	Jira        JiraConfig
-	Git         GitConfig
-	Preferences Preferences
+	Jira        JiraConfig  `toml:"jira"`
+	Git         GitConfig   `toml:"git"`
+	Preferences Preferences `toml:"preferences"`
+
+	// ActiveProfile and Profiles are optional; a config.toml with no
+	// [profiles.*] sections behaves exactly as before.
+	ActiveProfile string             `toml:"active_profile,omitempty"`
+	Profiles      map[string]Profile `toml:"profiles,omitempty"`
 }
 
 type JiraConfig struct {
-	URL        string
-	Email      string
-	ProjectKey string
-	AuthMethod AuthMethod
+	URL        string     `toml:"url"`
+	Email      string     `toml:"email"`
+	ProjectKey string     `toml:"project_key"`
+	AuthMethod AuthMethod `toml:"auth_method"`
 }
 
 type AuthMethod struct {
-	Type  string
-	Token string
+	Type string `toml:"type"`
+	// Token is either a literal secret or a "credential:target/userID"
+	// reference into the internal/auth store, resolved at load time by
+	// app.loadSettings so config.toml itself never has to hold a secret.
+	// It also supports "${ENV_VAR}" interpolation, resolved by Load.
+	Token string `toml:"token,omitempty"`
+
+	// OAuth1 fields, only populated when Type == "oauth1".
+	ConsumerKey    string `toml:"consumer_key,omitempty"`
+	PrivateKeyPath string `toml:"private_key_path,omitempty"`
+	AccessToken    string `toml:"access_token,omitempty"`
+	TokenSecret    string `toml:"token_secret,omitempty"`
 }
 
 type GitConfig struct {
-	Provider string
-	BaseURL  string
-	Token    string
-	Owner    string
-	Repo     string
+	Provider string `toml:"provider"`
+	BaseURL  string `toml:"base_url"`
+	// Token is either a literal secret or a "credential:target/userID"
+	// reference, same as AuthMethod.Token above. Also supports "${ENV_VAR}".
+	Token string `toml:"token,omitempty"`
+	Owner string `toml:"owner,omitempty"`
+	Repo  string `toml:"repo,omitempty"`
+
+	// APIVersion lets older self-hosted GitLab instances pin the v3 API
+	// path; empty means "use the provider's current default".
+	APIVersion string `toml:"api_version,omitempty"`
 }
 
 type Preferences struct {
-	BranchPrefix      string
-	DefaultTransition string
+	BranchPrefix        string `toml:"branch_prefix"`
+	DefaultTransition   string `toml:"default_transition"`
+	AutoCommentOnCommit bool   `toml:"auto_comment_on_commit"`
+
+	// CacheTTLMinutes controls when a plain `devflow list` serves from the
+	// local ticket cache instead of Jira (see cache.Store.IsFresh);
+	// `devflow list --offline` always serves from the cache. Zero means
+	// "use the built-in default" (see cache.DefaultTTL).
+	CacheTTLMinutes int `toml:"cache_ttl_minutes,omitempty"`
+
+	// Theme selects the built-in base theme ("dark" or "light") that
+	// internal/theme.Load overlays a theme.toml on top of. Empty means "dark".
+	Theme string `toml:"theme,omitempty"`
 }
 
+// Load reads configuration from the first file found in an ordered lookup
+// chain: ./devflow.toml (per-repo override), then
+// $XDG_CONFIG_HOME/devflow/config.toml, then the default ~/.devflow/config.toml.
+// String fields support "${ENV_VAR}" interpolation so CI can inject tokens
+// without writing them to disk.
 func Load() (*Settings, error) {
-	path, err := configPath()
+	path, err := firstExistingConfigPath()
 	if err != nil {
 		return nil, err
 	}
+	if path == "" {
+		return nil, ErrConfigNotFound
+	}
 
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -57,10 +100,12 @@ func Load() (*Settings, error) {
 	}
 
 	settings := &Settings{}
-	if err := parseTOML(string(data), settings); err != nil {
-		return nil, err
+	if err := toml.Unmarshal(data, settings); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
 	}
 
+	interpolateEnv(reflect.ValueOf(settings))
+
 	return settings, nil
 }
 
@@ -75,13 +120,18 @@ func (s *Settings) Save() error {
 		return fmt.Errorf("create config dir: %w", err)
 	}
 
+	data, err := toml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+
 	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
 	if err != nil {
 		return fmt.Errorf("write config: %w", err)
 	}
 	defer file.Close()
 
-	if _, err := file.WriteString(s.toTOML()); err != nil {
+	if _, err := file.Write(data); err != nil {
 		return fmt.Errorf("write config: %w", err)
 	}
 
@@ -92,6 +142,10 @@ func (s *Settings) Save() error {
 	return nil
 }
 
+// ConfigDir and ConfigPath always point at the user-level ~/.devflow
+// location: internal/auth and internal/cache key their own on-disk state off
+// it, and Save always writes there, regardless of which file Load reads
+// configuration from.
 func ConfigDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -112,131 +166,85 @@ func configPath() (string, error) {
 	return ConfigPath()
 }
 
-func parseTOML(contents string, settings *Settings) error {
-	scanner := bufio.NewScanner(strings.NewReader(contents))
-	section := ""
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
-			section = strings.TrimSpace(line[1 : len(line)-1])
-			continue
-		}
-
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
-			continue
-		}
-		key := strings.TrimSpace(parts[0])
-		value := parseValue(parts[1])
-
-		switch section {
-		case "jira":
-			assignJiraField(settings, key, value)
-		case "jira.auth_method":
-			assignJiraAuthField(settings, key, value)
-		case "git":
-			assignGitField(settings, key, value)
-		case "preferences":
-			assignPreferencesField(settings, key, value)
-		}
+// firstExistingConfigPath walks the lookup chain and returns the first file
+// that exists, or "" if none do.
+func firstExistingConfigPath() (string, error) {
+	candidates, err := configLookupChain()
+	if err != nil {
+		return "", err
 	}
-
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("parse config: %w", err)
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
 	}
-
-	return nil
+	return "", nil
 }
 
-func assignJiraField(s *Settings, key, value string) {
-	switch key {
-	case "url":
-		s.Jira.URL = value
-	case "email":
-		s.Jira.Email = value
-	case "project_key":
-		s.Jira.ProjectKey = value
-	}
-}
+// configLookupChain returns candidate config paths in priority order:
+// ./devflow.toml, $XDG_CONFIG_HOME/devflow/config.toml, ~/.devflow/config.toml.
+func configLookupChain() ([]string, error) {
+	var candidates []string
 
-func assignJiraAuthField(s *Settings, key, value string) {
-	switch key {
-	case "type":
-		s.Jira.AuthMethod.Type = value
-	case "token":
-		s.Jira.AuthMethod.Token = value
+	if cwd, err := os.Getwd(); err == nil {
+		candidates = append(candidates, filepath.Join(cwd, "devflow.toml"))
 	}
-}
 
-func assignGitField(s *Settings, key, value string) {
-	switch key {
-	case "provider":
-		s.Git.Provider = value
-	case "base_url":
-		s.Git.BaseURL = value
-	case "token":
-		s.Git.Token = value
-	case "owner":
-		s.Git.Owner = value
-	case "repo":
-		s.Git.Repo = value
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		candidates = append(candidates, filepath.Join(xdg, "devflow", "config.toml"))
 	}
-}
 
-func assignPreferencesField(s *Settings, key, value string) {
-	switch key {
-	case "branch_prefix":
-		s.Preferences.BranchPrefix = value
-	case "default_transition":
-		s.Preferences.DefaultTransition = value
+	defaultPath, err := configPath()
+	if err != nil {
+		return nil, err
 	}
-}
+	candidates = append(candidates, defaultPath)
 
-func parseValue(raw string) string {
-	trimmed := strings.TrimSpace(raw)
-	trimmed = strings.TrimPrefix(trimmed, "\"")
-	trimmed = strings.TrimSuffix(trimmed, "\"")
-	return trimmed
+	return candidates, nil
 }
 
-func (s *Settings) toTOML() string {
-	var b strings.Builder
-	b.WriteString("[jira]\n")
-	b.WriteString(fmt.Sprintf("url = \"%s\"\n", escape(s.Jira.URL)))
-	b.WriteString(fmt.Sprintf("email = \"%s\"\n", escape(s.Jira.Email)))
-	b.WriteString(fmt.Sprintf("project_key = \"%s\"\n\n", escape(s.Jira.ProjectKey)))
-
-	b.WriteString("[jira.auth_method]\n")
-	b.WriteString(fmt.Sprintf("type = \"%s\"\n", escape(s.Jira.AuthMethod.Type)))
-	b.WriteString(fmt.Sprintf("token = \"%s\"\n\n", escape(s.Jira.AuthMethod.Token)))
-
-	b.WriteString("[git]\n")
-	b.WriteString(fmt.Sprintf("provider = \"%s\"\n", escape(s.Git.Provider)))
-	b.WriteString(fmt.Sprintf("base_url = \"%s\"\n", escape(s.Git.BaseURL)))
-	b.WriteString(fmt.Sprintf("token = \"%s\"\n", escape(s.Git.Token)))
-	if s.Git.Owner != "" {
-		b.WriteString(fmt.Sprintf("owner = \"%s\"\n", escape(s.Git.Owner)))
-	}
-	if s.Git.Repo != "" {
-		b.WriteString(fmt.Sprintf("repo = \"%s\"\n", escape(s.Git.Repo)))
-	}
-	b.WriteString("\n")
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
 
-	b.WriteString("[preferences]\n")
-	b.WriteString(fmt.Sprintf("branch_prefix = \"%s\"\n", escape(s.Preferences.BranchPrefix)))
-	b.WriteString(fmt.Sprintf("default_transition = \"%s\"\n", escape(s.Preferences.DefaultTransition)))
-	b.WriteString("\n")
+// interpolateEnv walks every string field reachable from v and replaces
+// "${ENV_VAR}" references with the environment variable's value, leaving
+// unset variables untouched so a typo doesn't silently blank out a field.
+func interpolateEnv(v reflect.Value) {
+	if !v.IsValid() {
+		return
+	}
 
-	return b.String()
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			interpolateEnv(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			interpolateEnv(v.Field(i))
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			entry := v.MapIndex(key)
+			resolved := reflect.New(entry.Type()).Elem()
+			resolved.Set(entry)
+			interpolateEnv(resolved)
+			v.SetMapIndex(key, resolved)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(expandEnvRefs(v.String()))
+		}
+	}
 }
 
-func escape(value string) string {
-	value = strings.ReplaceAll(value, "\\", "\\\\")
-	value = strings.ReplaceAll(value, "\"", "\\\"")
-	return value
+func expandEnvRefs(value string) string {
+	return envRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envRefPattern.FindStringSubmatch(ref)[1]
+		if expanded, ok := os.LookupEnv(name); ok {
+			return expanded
+		}
+		return ref
+	})
 }
 
 func MaskToken(token string) string {
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Credential is a single stored secret, addressable by ID and scoped to a
+// target system ("jira", "github", "gitlab", ...). Profiles reference
+// credentials by ID instead of embedding tokens directly, so the same
+// credential can be shared across profiles and rotated in one place.
+type Credential struct {
+	ID     string
+	Target string
+	Auth   AuthMethod
+}
+
+func CredentialsDir() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "credentials"), nil
+}
+
+func credentialPath(id string) (string, error) {
+	dir, err := CredentialsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, id+".json"), nil
+}
+
+// NewCredentialID returns a random, URL-safe identifier for a new credential.
+func NewCredentialID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate credential id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// SaveCredential persists c under its ID, creating the credentials directory
+// on first use. A blank ID is assigned automatically.
+func SaveCredential(c *Credential) error {
+	if c.ID == "" {
+		id, err := NewCredentialID()
+		if err != nil {
+			return err
+		}
+		c.ID = id
+	}
+
+	dir, err := CredentialsDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create credentials dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode credential: %w", err)
+	}
+
+	path, err := credentialPath(c.ID)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write credential: %w", err)
+	}
+	return nil
+}
+
+func LoadCredential(id string) (*Credential, error) {
+	if id == "" {
+		return nil, fmt.Errorf("credential id is empty")
+	}
+	path, err := credentialPath(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("credential %q not found", id)
+		}
+		return nil, fmt.Errorf("read credential: %w", err)
+	}
+
+	var c Credential
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parse credential: %w", err)
+	}
+	return &c, nil
+}
+
+func RemoveCredential(id string) error {
+	path, err := credentialPath(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove credential: %w", err)
+	}
+	return nil
+}
+
+func ListCredentials() ([]*Credential, error) {
+	dir, err := CredentialsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("list credentials: %w", err)
+	}
+
+	var creds []*Credential
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		id := entry.Name()
+		if filepath.Ext(id) == ".json" {
+			id = id[:len(id)-len(".json")]
+		}
+		c, err := LoadCredential(id)
+		if err != nil {
+			continue
+		}
+		creds = append(creds, c)
+	}
+	return creds, nil
+}
@@ -0,0 +1,70 @@
+package config
+
+import "fmt"
+
+// JiraRef and GitRef describe a profile's connection details without
+// embedding secrets directly; the actual token lives in the credential
+// store and is referenced by CredentialID.
+type JiraRef struct {
+	URL          string `toml:"url"`
+	Email        string `toml:"email"`
+	ProjectKey   string `toml:"project_key"`
+	CredentialID string `toml:"credential_id,omitempty"`
+}
+
+type GitRef struct {
+	Provider     string `toml:"provider"`
+	BaseURL      string `toml:"base_url"`
+	Owner        string `toml:"owner,omitempty"`
+	Repo         string `toml:"repo,omitempty"`
+	CredentialID string `toml:"credential_id,omitempty"`
+}
+
+type Profile struct {
+	Jira        JiraRef     `toml:"jira"`
+	Git         GitRef      `toml:"git"`
+	Preferences Preferences `toml:"preferences"`
+}
+
+var ErrProfileNotFound = fmt.Errorf("profile not found")
+
+// ResolveProfile joins the named profile with its referenced credentials and
+// returns a ready-to-use Settings, the same shape loadSettings has always
+// handed to command handlers.
+func (s *Settings) ResolveProfile(name string) (*Settings, error) {
+	profile, ok := s.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found: %w", name, ErrProfileNotFound)
+	}
+
+	resolved := &Settings{Preferences: profile.Preferences}
+
+	resolved.Jira = JiraConfig{
+		URL:        profile.Jira.URL,
+		Email:      profile.Jira.Email,
+		ProjectKey: profile.Jira.ProjectKey,
+	}
+	if profile.Jira.CredentialID != "" {
+		cred, err := LoadCredential(profile.Jira.CredentialID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve jira credential for profile %q: %w", name, err)
+		}
+		resolved.Jira.AuthMethod = cred.Auth
+	}
+
+	resolved.Git = GitConfig{
+		Provider: profile.Git.Provider,
+		BaseURL:  profile.Git.BaseURL,
+		Owner:    profile.Git.Owner,
+		Repo:     profile.Git.Repo,
+	}
+	if profile.Git.CredentialID != "" {
+		cred, err := LoadCredential(profile.Git.CredentialID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve git credential for profile %q: %w", name, err)
+		}
+		resolved.Git.Token = cred.Auth.Token
+	}
+
+	return resolved, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/Ilia01/devflow/internal/utils"
 )
@@ -107,6 +108,52 @@ func (c *Client) Root() string {
 	return c.worktree
 }
 
+type LogEntry struct {
+	SHA       string
+	Subject   string
+	Timestamp time.Time
+}
+
+// Log returns up to limit commits reachable from ref, most recent first.
+func (c *Client) Log(ref string, limit int) ([]LogEntry, error) {
+	out, err := runInDir(c.worktree, "log", fmt.Sprintf("-%d", limit), "--pretty=format:%H\x1f%s\x1f%cI", ref)
+	if err != nil {
+		return nil, err
+	}
+	return parseLogEntries(out), nil
+}
+
+// CommitsSince returns the commits reachable from HEAD but not from base,
+// e.g. the commits made on a feature branch since it diverged from main.
+func (c *Client) CommitsSince(base string) ([]LogEntry, error) {
+	out, err := runInDir(c.worktree, "log", fmt.Sprintf("%s..HEAD", base), "--pretty=format:%H\x1f%s\x1f%cI")
+	if err != nil {
+		return nil, err
+	}
+	return parseLogEntries(out), nil
+}
+
+func parseLogEntries(out string) []LogEntry {
+	trimmed := strings.TrimSpace(out)
+	if trimmed == "" {
+		return nil
+	}
+
+	var entries []LogEntry
+	for _, line := range strings.Split(trimmed, "\n") {
+		parts := strings.SplitN(line, "\x1f", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, parts[2])
+		if err != nil {
+			continue
+		}
+		entries = append(entries, LogEntry{SHA: parts[0], Subject: parts[1], Timestamp: ts})
+	}
+	return entries
+}
+
 func runInDir(dir string, args ...string) (string, error) {
 	cmd := exec.Command("git", args...)
 	if dir != "" {
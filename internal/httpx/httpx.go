@@ -0,0 +1,306 @@
+// Package httpx is the shared HTTP transport for devflow's API clients
+// (jira, github, gitlab, bitbucket, gitea): it retries 429/5xx responses
+// with backoff and jitter, honoring Retry-After and RateLimit-Reset
+// headers, injects auth via a pluggable AuthInjector, and turns non-2xx
+// responses into a typed *APIError so callers can branch on errors.Is/
+// errors.As instead of grepping response bodies.
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors an APIError's Unwrap exposes, so callers can test
+// with errors.Is(err, httpx.ErrNotFound) regardless of which API returned it.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// Doer is satisfied by *http.Client; tests swap in a fake (e.g. the
+// repo's roundTripFunc pattern via http.Client.Transport) instead of
+// implementing it directly.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// APIError is returned for any non-2xx response once retries are
+// exhausted. Message is the provider's own error text, extracted from
+// Jira's {"errorMessages": [...], "errors": {...}} envelope, GitLab's
+// {"message": ...}, or GitHub's {"errors": [{"code": ...}]}, falling back
+// to the raw body. Callers match specific failures (e.g. "PR already
+// exists") with errors.As(&httpx.APIError{}) and inspecting Code/Message,
+// or with errors.Is against the Err* sentinels for the common cases.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Raw        []byte
+	RequestID  string
+	err        error
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("http %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("http %d", e.StatusCode)
+}
+
+func (e *APIError) Unwrap() error { return e.err }
+
+const (
+	defaultMaxRetries = 3
+	defaultBaseDelay  = 500 * time.Millisecond
+)
+
+// AuthInjector authenticates an outgoing request, e.g. by setting an
+// Authorization header or computing a request signature. It runs once,
+// when the request is built, so callers that need to re-derive a
+// signature per attempt (OAuth1's nonce) should still do so inline
+// before handing the request to Do.
+type AuthInjector func(req *http.Request) error
+
+// Client wraps a Doer with retry behavior. The zero value is not usable;
+// construct one with NewClient.
+type Client struct {
+	Doer       Doer
+	MaxRetries int
+	BaseDelay  time.Duration
+
+	// Auth, if set, is applied by NewRequest to every request it builds.
+	Auth AuthInjector
+}
+
+// NewClient wraps doer (typically an *http.Client) with the default
+// retry policy: 3 retries, 500ms base delay.
+func NewClient(doer Doer) *Client {
+	return &Client{Doer: doer, MaxRetries: defaultMaxRetries, BaseDelay: defaultBaseDelay}
+}
+
+// NewRequest builds an *http.Request and, if Auth is set, authenticates
+// it, so callers don't each repeat the "build request, set headers"
+// boilerplate around every API call.
+func (c *Client) NewRequest(method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.Auth != nil {
+		if err := c.Auth(req); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// DoJSON executes req via Do and, if out is non-nil, unmarshals the
+// response body into it.
+func (c *Client) DoJSON(req *http.Request, out any) error {
+	data, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("parse response: %w", err)
+	}
+	return nil
+}
+
+// Do executes req, retrying 429/5xx responses (and transport errors) with
+// exponential backoff and jitter, honoring Retry-After and
+// RateLimit-Reset when present. It returns the response body on success;
+// once retries are exhausted it returns an *APIError (wrapping one of the
+// Err* sentinels where applicable) for a non-2xx response, or the last
+// transport error as-is.
+func (c *Client) Do(req *http.Request) ([]byte, error) {
+	var lastErr error
+	var nextDelay time.Duration
+
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("rewind request body for retry: %w", err)
+				}
+				req.Body = body
+			}
+			time.Sleep(nextDelay)
+		}
+
+		resp, err := c.Doer.Do(req)
+		if err != nil {
+			if attempt == c.MaxRetries {
+				return nil, err
+			}
+			lastErr = err
+			nextDelay = backoff(attempt, c.BaseDelay)
+			continue
+		}
+
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return data, nil
+		}
+
+		if isRetryable(resp.StatusCode) && attempt < c.MaxRetries {
+			nextDelay = retryDelay(resp, attempt, c.BaseDelay)
+			continue
+		}
+
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       parseErrorCode(data),
+			Message:    parseErrorMessage(data),
+			Raw:        data,
+			RequestID:  requestID(resp.Header),
+			err:        sentinelFor(resp.StatusCode),
+		}
+	}
+
+	return nil, lastErr
+}
+
+func isRetryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+func sentinelFor(statusCode int) error {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// parseErrorMessage extracts a human-readable message from a Jira or
+// GitLab error body, falling back to the raw (trimmed) body.
+func parseErrorMessage(body []byte) string {
+	var jiraErr struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if json.Unmarshal(body, &jiraErr) == nil && (len(jiraErr.ErrorMessages) > 0 || len(jiraErr.Errors) > 0) {
+		parts := append([]string{}, jiraErr.ErrorMessages...)
+		for field, msg := range jiraErr.Errors {
+			parts = append(parts, fmt.Sprintf("%s: %s", field, msg))
+		}
+		return strings.Join(parts, "; ")
+	}
+
+	var gitlabErr struct {
+		Message json.RawMessage `json:"message"`
+	}
+	if json.Unmarshal(body, &gitlabErr) == nil && len(gitlabErr.Message) > 0 {
+		var s string
+		if json.Unmarshal(gitlabErr.Message, &s) == nil {
+			return s
+		}
+		return string(gitlabErr.Message)
+	}
+
+	return strings.TrimSpace(string(body))
+}
+
+// parseErrorCode extracts GitHub's per-error "code" field
+// ({"errors": [{"code": "custom", ...}]}), returning "" for providers
+// (Jira, GitLab) that don't surface a machine-readable code.
+func parseErrorCode(body []byte) string {
+	var githubErr struct {
+		Errors []struct {
+			Code string `json:"code"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(body, &githubErr) == nil && len(githubErr.Errors) > 0 {
+		return githubErr.Errors[0].Code
+	}
+	return ""
+}
+
+// requestID returns the first request-correlation header a response sets,
+// so an APIError can be cross-referenced with provider support/logs.
+func requestID(h http.Header) string {
+	for _, name := range []string{"X-GitHub-Request-Id", "X-Request-Id", "Request-Id"} {
+		if v := h.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// retryDelay picks how long to wait before the next attempt: Retry-After
+// takes priority, then GitLab's RateLimit-Reset, falling back to backoff.
+func retryDelay(resp *http.Response, attempt int, base time.Duration) time.Duration {
+	if d, ok := retryAfterDelay(resp.Header.Get("Retry-After")); ok {
+		return d
+	}
+	if d, ok := rateLimitResetDelay(resp.Header.Get("RateLimit-Reset")); ok {
+		return d
+	}
+	return backoff(attempt, base)
+}
+
+func retryAfterDelay(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return delayUntil(when), true
+	}
+	return 0, false
+}
+
+func rateLimitResetDelay(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	epoch, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return delayUntil(time.Unix(epoch, 0)), true
+}
+
+func delayUntil(when time.Time) time.Duration {
+	if d := time.Until(when); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// backoff returns a random delay between half and all of base*2^attempt, so concurrent
+// callers retrying the same rate limit don't all wake up in lockstep.
+func backoff(attempt int, base time.Duration) time.Duration {
+	delay := base * time.Duration(1<<attempt)
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay/2 + jitter
+}
@@ -0,0 +1,160 @@
+package httpx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeDoer struct {
+	responses []*http.Response
+	errs      []error
+	calls     int
+}
+
+func (f *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	i := f.calls
+	f.calls++
+	if i < len(f.errs) && f.errs[i] != nil {
+		return nil, f.errs[i]
+	}
+	return f.responses[i], nil
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}
+}
+
+func newRequest(t *testing.T) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/api", bytes.NewReader([]byte(`{}`)))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	return req
+}
+
+func testClient(doer Doer) *Client {
+	return &Client{Doer: doer, MaxRetries: 3, BaseDelay: time.Millisecond}
+}
+
+func TestDoSucceedsImmediately(t *testing.T) {
+	doer := &fakeDoer{responses: []*http.Response{jsonResponse(http.StatusOK, `{"ok":true}`)}}
+	data, err := testClient(doer).Do(newRequest(t))
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if string(data) != `{"ok":true}` {
+		t.Fatalf("data = %s", data)
+	}
+	if doer.calls != 1 {
+		t.Fatalf("calls = %d, want 1", doer.calls)
+	}
+}
+
+func TestDoRetriesOn500ThenSucceeds(t *testing.T) {
+	doer := &fakeDoer{responses: []*http.Response{
+		jsonResponse(http.StatusInternalServerError, `oops`),
+		jsonResponse(http.StatusOK, `{"ok":true}`),
+	}}
+	data, err := testClient(doer).Do(newRequest(t))
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if string(data) != `{"ok":true}` {
+		t.Fatalf("data = %s", data)
+	}
+	if doer.calls != 2 {
+		t.Fatalf("calls = %d, want 2", doer.calls)
+	}
+}
+
+func TestDoGivesUpAfterMaxRetries(t *testing.T) {
+	doer := &fakeDoer{responses: []*http.Response{
+		jsonResponse(http.StatusInternalServerError, "1"),
+		jsonResponse(http.StatusInternalServerError, "2"),
+		jsonResponse(http.StatusInternalServerError, "3"),
+		jsonResponse(http.StatusInternalServerError, "4"),
+	}}
+	_, err := testClient(doer).Do(newRequest(t))
+	if err == nil {
+		t.Fatalf("expected error after exhausting retries")
+	}
+	if doer.calls != 4 {
+		t.Fatalf("calls = %d, want 4 (1 + 3 retries)", doer.calls)
+	}
+}
+
+func TestDoHonorsRetryAfterSeconds(t *testing.T) {
+	rateLimited := jsonResponse(http.StatusTooManyRequests, `{"message":"slow down"}`)
+	rateLimited.Header.Set("Retry-After", "0")
+	doer := &fakeDoer{responses: []*http.Response{rateLimited, jsonResponse(http.StatusOK, "ok")}}
+
+	start := time.Now()
+	if _, err := testClient(doer).Do(newRequest(t)); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("took too long honoring Retry-After: %s", elapsed)
+	}
+}
+
+func TestDoMapsStatusToSentinels(t *testing.T) {
+	cases := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+	}
+	for _, tc := range cases {
+		doer := &fakeDoer{responses: []*http.Response{jsonResponse(tc.status, `{}`)}}
+		_, err := testClient(doer).Do(newRequest(t))
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("status %d: err = %v, want wrapping %v", tc.status, err, tc.want)
+		}
+	}
+}
+
+func TestDoExhaustedRateLimitMapsToErrRateLimited(t *testing.T) {
+	doer := &fakeDoer{responses: []*http.Response{
+		jsonResponse(http.StatusTooManyRequests, `{}`),
+		jsonResponse(http.StatusTooManyRequests, `{}`),
+		jsonResponse(http.StatusTooManyRequests, `{}`),
+		jsonResponse(http.StatusTooManyRequests, `{}`),
+	}}
+	_, err := testClient(doer).Do(newRequest(t))
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("err = %v, want wrapping ErrRateLimited", err)
+	}
+}
+
+func TestParseErrorMessageJira(t *testing.T) {
+	body := []byte(`{"errorMessages":["ticket not found"],"errors":{"assignee":"invalid user"}}`)
+	msg := parseErrorMessage(body)
+	if !strings.Contains(msg, "ticket not found") || !strings.Contains(msg, "assignee: invalid user") {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+}
+
+func TestParseErrorMessageGitLab(t *testing.T) {
+	if msg := parseErrorMessage([]byte(`{"message":"401 Unauthorized"}`)); msg != "401 Unauthorized" {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+}
+
+func TestParseErrorMessageFallsBackToRawBody(t *testing.T) {
+	if msg := parseErrorMessage([]byte(`not json`)); msg != "not json" {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+}
@@ -2,15 +2,19 @@ package jira
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/Ilia01/devflow/internal/auth"
 	"github.com/Ilia01/devflow/internal/config"
+	"github.com/Ilia01/devflow/internal/httpx"
 	"github.com/Ilia01/devflow/internal/models"
 )
 
@@ -19,16 +23,58 @@ type Client struct {
 	email   string
 	auth    config.AuthMethod
 	http    *http.Client
+	retrier *httpx.Client
+
+	oauth1 *oauth1Signer
 }
 
 func NewClient(baseURL, email string, auth config.AuthMethod) *Client {
-	return &Client{
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	c := &Client{
 		baseURL: strings.TrimRight(baseURL, "/"),
 		email:   email,
 		auth:    auth,
-		http: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		http:    httpClient,
+		retrier: httpx.NewClient(httpClient),
+	}
+	c.retrier.Auth = c.applyAuth
+
+	if auth.Type == "oauth1" && auth.ConsumerKey != "" && auth.PrivateKeyPath != "" {
+		if signer, err := newOAuth1Signer(auth.ConsumerKey, auth.PrivateKeyPath, auth.AccessToken, auth.TokenSecret); err == nil {
+			c.oauth1 = signer
+		}
+	}
+
+	return c
+}
+
+// NewClientWithCredential builds a Client from an auth.Credential rather
+// than a config.AuthMethod, for callers going through the target+userID
+// credential store (see internal/auth) instead of a profile's embedded
+// AuthMethod. It supports the same three schemes NewClient does: a
+// LoginPasswordCredential signs Basic (the api_token flow), a
+// TokenCredential or OAuth2Credential sign Bearer, and an OAuth1Credential
+// is handed to the same RSA-SHA1 signer NewClient builds for "oauth1".
+func NewClientWithCredential(baseURL string, cred auth.Credential) (*Client, error) {
+	switch c := cred.(type) {
+	case *auth.LoginPasswordCredential:
+		return NewClient(baseURL, c.Login, config.AuthMethod{Type: "api_token", Token: c.Password}), nil
+	case *auth.TokenCredential:
+		return NewClient(baseURL, c.UserID(), config.AuthMethod{Type: "personal_access_token", Token: c.Token}), nil
+	case *auth.OAuth2Credential:
+		return NewClient(baseURL, c.UserID(), config.AuthMethod{Type: "personal_access_token", Token: c.AccessToken}), nil
+	case *auth.OAuth1Credential:
+		return NewClient(baseURL, c.UserID(), config.AuthMethod{
+			Type:           "oauth1",
+			ConsumerKey:    c.ConsumerKey,
+			PrivateKeyPath: c.PrivateKeyPath,
+			AccessToken:    c.AccessToken,
+			TokenSecret:    c.TokenSecret,
+		}), nil
+	default:
+		return nil, fmt.Errorf("jira: unsupported credential kind %q", cred.Kind())
 	}
 }
 
@@ -43,37 +89,78 @@ func (c *Client) buildURL(path string) string {
 	return fmt.Sprintf("%s%s", c.baseURL, path)
 }
 
-func (c *Client) applyAuth(req *http.Request) {
+func (c *Client) applyAuth(req *http.Request) error {
 	switch c.auth.Type {
 	case "personal_access_token":
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.auth.Token))
+	case "oauth1":
+		if c.oauth1 == nil {
+			return fmt.Errorf("oauth1 credentials not configured; run 'devflow init' again")
+		}
+		header, err := c.oauth1.sign(req, c.auth.AccessToken, c.auth.TokenSecret)
+		if err != nil {
+			return fmt.Errorf("sign oauth1 request: %w", err)
+		}
+		req.Header.Set("Authorization", header)
 	default:
 		req.SetBasicAuth(c.email, c.auth.Token)
 	}
+	return nil
+}
+
+// FetchOptions narrows which fields Jira returns for a ticket lookup or
+// search, and which computed properties (e.g. "renderedFields" for ADF
+// rendering) get expanded. A zero value requests Jira's defaults.
+type FetchOptions struct {
+	Fields []string
+	Expand []string
+}
+
+func firstFetchOptions(opts []FetchOptions) FetchOptions {
+	if len(opts) == 0 {
+		return FetchOptions{}
+	}
+	return opts[0]
+}
+
+func (o FetchOptions) queryString() string {
+	values := url.Values{}
+	if len(o.Fields) > 0 {
+		values.Set("fields", strings.Join(o.Fields, ","))
+	}
+	if len(o.Expand) > 0 {
+		values.Set("expand", strings.Join(o.Expand, ","))
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	return "?" + values.Encode()
 }
 
-func (c *Client) GetTicket(ticketID string) (*models.JiraTicket, error) {
-	url := fmt.Sprintf("%s/rest/api/%s/issue/%s", c.baseURL, c.apiVersion(), ticketID)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (c *Client) GetTicket(ticketID string, opts ...FetchOptions) (*models.JiraTicket, error) {
+	o := firstFetchOptions(opts)
+	reqURL := fmt.Sprintf("%s/rest/api/%s/issue/%s%s", c.baseURL, c.apiVersion(), ticketID, o.queryString())
+	req, err := c.retrier.NewRequest(http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, err
 	}
-	c.applyAuth(req)
 
 	var ticket models.JiraTicket
-	if err := c.doJSON(req, &ticket); err != nil {
+	if err := c.retrier.DoJSON(req, &ticket); err != nil {
 		return nil, err
 	}
 	return &ticket, nil
 }
 
-func (c *Client) UpdateStatus(ticketID, transitionName string) error {
+func (c *Client) availableTransitions(ticketID string) ([]struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}, error) {
 	url := fmt.Sprintf("%s/rest/api/%s/issue/%s/transitions", c.baseURL, c.apiVersion(), ticketID)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	req, err := c.retrier.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	c.applyAuth(req)
 
 	var result struct {
 		Transitions []struct {
@@ -81,13 +168,35 @@ func (c *Client) UpdateStatus(ticketID, transitionName string) error {
 			Name string `json:"name"`
 		} `json:"transitions"`
 	}
+	if err := c.retrier.DoJSON(req, &result); err != nil {
+		return nil, err
+	}
+	return result.Transitions, nil
+}
 
-	if err := c.doJSON(req, &result); err != nil {
+// ListTransitions returns the names of the transitions currently
+// available for ticketID, in the order Jira reports them.
+func (c *Client) ListTransitions(ticketID string) ([]string, error) {
+	transitions, err := c.availableTransitions(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(transitions))
+	for i, t := range transitions {
+		names[i] = t.Name
+	}
+	return names, nil
+}
+
+func (c *Client) UpdateStatus(ticketID, transitionName string) error {
+	url := fmt.Sprintf("%s/rest/api/%s/issue/%s/transitions", c.baseURL, c.apiVersion(), ticketID)
+	transitions, err := c.availableTransitions(ticketID)
+	if err != nil {
 		return err
 	}
 
 	var transitionID string
-	for _, t := range result.Transitions {
+	for _, t := range transitions {
 		if strings.EqualFold(t.Name, transitionName) {
 			transitionID = t.ID
 			break
@@ -105,92 +214,232 @@ func (c *Client) UpdateStatus(ticketID, transitionName string) error {
 		return err
 	}
 
-	req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	req, err := c.retrier.NewRequest(http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	c.applyAuth(req)
 
-	if err := c.do(req, nil); err != nil {
+	if err := c.retrier.DoJSON(req, nil); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *Client) SearchWithJQL(jql string, limit int) ([]models.JiraTicket, error) {
-	url := fmt.Sprintf("%s/rest/api/%s/search", c.baseURL, c.apiVersion())
+// SearchPage is one page of SearchWithJQLPage results, carrying the
+// startAt/total paging metadata Jira returns alongside the matched issues
+// so a caller can tell whether more pages remain.
+type SearchPage struct {
+	Issues  []models.JiraTicket
+	StartAt int
+	Total   int
+}
+
+// SearchWithJQLPage runs jql starting at startAt and returning up to
+// maxResults issues, along with Jira's reported total match count, so a
+// caller can page through a result set larger than one response. ctx
+// cancels the in-flight request.
+func (c *Client) SearchWithJQLPage(ctx context.Context, jql string, startAt, maxResults int, opts ...FetchOptions) (SearchPage, error) {
+	o := firstFetchOptions(opts)
+	fields := o.Fields
+	if len(fields) == 0 {
+		fields = []string{"summary", "status", "assignee", "updated"}
+	}
+
+	reqURL := fmt.Sprintf("%s/rest/api/%s/search", c.baseURL, c.apiVersion())
 	payload := map[string]any{
 		"jql":        jql,
-		"fields":     []string{"summary", "status", "assignee"},
-		"maxResults": limit,
+		"fields":     fields,
+		"startAt":    startAt,
+		"maxResults": maxResults,
+	}
+	if len(o.Expand) > 0 {
+		payload["expand"] = o.Expand
 	}
 	body, err := json.Marshal(payload)
 	if err != nil {
-		return nil, err
+		return SearchPage{}, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	req, err := c.retrier.NewRequest(http.MethodPost, reqURL, bytes.NewReader(body))
 	if err != nil {
-		return nil, err
+		return SearchPage{}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	c.applyAuth(req)
+	req = req.WithContext(ctx)
 
 	var response struct {
-		Issues []models.JiraTicket `json:"issues"`
+		Issues  []models.JiraTicket `json:"issues"`
+		StartAt int                 `json:"startAt"`
+		Total   int                 `json:"total"`
 	}
-	if err := c.doJSON(req, &response); err != nil {
+	if err := c.retrier.DoJSON(req, &response); err != nil {
+		return SearchPage{}, err
+	}
+	return SearchPage{Issues: response.Issues, StartAt: response.StartAt, Total: response.Total}, nil
+}
+
+// SearchWithJQL runs jql and returns up to limit matching issues in a
+// single page, for callers (list/search commands) that want a bounded
+// top-N lookup rather than the full result set. Use SearchWithJQLPage
+// directly to page through everything a query matches.
+func (c *Client) SearchWithJQL(jql string, limit int, opts ...FetchOptions) ([]models.JiraTicket, error) {
+	page, err := c.SearchWithJQLPage(context.Background(), jql, 0, limit, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return response.Issues, nil
+	return page.Issues, nil
 }
 
-func (c *Client) SearchAssigned(projectKey string) ([]models.JiraTicket, error) {
-	jql := fmt.Sprintf("assignee = currentUser() AND project = %s", projectKey)
-	return c.SearchWithJQL(jql, 50)
+func (c *Client) GetComments(ticketID string) ([]models.JiraComment, error) {
+	url := fmt.Sprintf("%s/rest/api/%s/issue/%s/comment", c.baseURL, c.apiVersion(), ticketID)
+	req, err := c.retrier.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Comments []models.JiraComment `json:"comments"`
+	}
+	if err := c.retrier.DoJSON(req, &response); err != nil {
+		return nil, err
+	}
+	return response.Comments, nil
 }
 
-func (c *Client) TestConnection() error {
-	url := fmt.Sprintf("%s/rest/api/%s/myself", c.baseURL, c.apiVersion())
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (c *Client) AddComment(ticketID, body string) error {
+	url := fmt.Sprintf("%s/rest/api/%s/issue/%s/comment", c.baseURL, c.apiVersion(), ticketID)
+	payload := map[string]string{"body": body}
+	data, err := json.Marshal(payload)
 	if err != nil {
 		return err
 	}
-	c.applyAuth(req)
-	return c.do(req, nil)
-}
 
-func (c *Client) doJSON(req *http.Request, v any) error {
-	return c.do(req, func(body []byte) error {
-		if v == nil {
-			return nil
-		}
-		if err := json.Unmarshal(body, v); err != nil {
-			return fmt.Errorf("parse response: %w", err)
-		}
-		return nil
-	})
+	req, err := c.retrier.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.retrier.DoJSON(req, nil)
 }
 
-func (c *Client) do(req *http.Request, handler func([]byte) error) error {
-	resp, err := c.http.Do(req)
+// CreateIssueLink links two issues under the named link type (e.g. "Blocks").
+// outward is the issue the type's outward description applies to (for
+// "Blocks", the blocker); inward is the other side ("is blocked by").
+func (c *Client) CreateIssueLink(inward, outward, linkType string) error {
+	url := fmt.Sprintf("%s/rest/api/%s/issueLink", c.baseURL, c.apiVersion())
+	payload := map[string]any{
+		"type":         map[string]string{"name": linkType},
+		"inwardIssue":  map[string]string{"key": inward},
+		"outwardIssue": map[string]string{"key": outward},
+	}
+	body, err := json.Marshal(payload)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	req, err := c.retrier.NewRequest(http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return err
 	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.retrier.DoJSON(req, nil)
+}
+
+// SubtaskFields describes a new subtask; ProjectKey and Assignee are
+// normally inherited from the parent ticket by the caller.
+type SubtaskFields struct {
+	Summary     string
+	Description string
+	ProjectKey  string
+	Assignee    string
+}
+
+func (c *Client) CreateSubtask(parentID string, fields SubtaskFields) (*models.JiraTicket, error) {
+	url := fmt.Sprintf("%s/rest/api/%s/issue", c.baseURL, c.apiVersion())
+
+	issueFields := map[string]any{
+		"project":   map[string]string{"key": fields.ProjectKey},
+		"parent":    map[string]string{"key": parentID},
+		"summary":   fields.Summary,
+		"issuetype": map[string]string{"name": "Sub-task"},
+	}
+	if fields.Description != "" {
+		issueFields["description"] = fields.Description
+	}
+	if fields.Assignee != "" {
+		issueFields["assignee"] = map[string]string{"name": fields.Assignee}
+	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("jira api error (%d): %s", resp.StatusCode, string(data))
+	payload := map[string]any{"fields": issueFields}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
 	}
 
-	if handler != nil {
-		return handler(data)
+	req, err := c.retrier.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := c.retrier.DoJSON(req, &created); err != nil {
+		return nil, err
+	}
+
+	return c.GetTicket(created.Key)
+}
+
+// GetIssueLinks returns the current issue links for a ticket. Jira returns
+// issuelinks as part of the default issue payload, so this just re-fetches
+// the ticket and reads the field back out.
+func (c *Client) GetIssueLinks(ticketID string) ([]models.JiraLink, error) {
+	ticket, err := c.GetTicket(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return ticket.Fields.IssueLinks, nil
+}
+
+// FormatIssueLinks renders links as "- <relation>: <KEY> <summary>" lines,
+// where relation is the link type's outward or inward description (e.g.
+// "- blocks: WAB-42 Fix login redirect"), for embedding related work
+// into a generated PR description. Links with neither side resolved are
+// skipped.
+func FormatIssueLinks(links []models.JiraLink) string {
+	var lines []string
+	for _, link := range links {
+		switch {
+		case link.OutwardIssue != nil:
+			lines = append(lines, fmt.Sprintf("- %s: %s %s", link.Type.Outward, link.OutwardIssue.Key, link.OutwardIssue.Fields.Summary))
+		case link.InwardIssue != nil:
+			lines = append(lines, fmt.Sprintf("- %s: %s %s", link.Type.Inward, link.InwardIssue.Key, link.InwardIssue.Fields.Summary))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *Client) SearchAssigned(projectKey string) ([]models.JiraTicket, error) {
+	jql := fmt.Sprintf("assignee = currentUser() AND project = %s", projectKey)
+	return c.SearchWithJQL(jql, 50)
+}
+
+func (c *Client) TestConnection() error {
+	url := fmt.Sprintf("%s/rest/api/%s/myself", c.baseURL, c.apiVersion())
+	req, err := c.retrier.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if err := c.retrier.DoJSON(req, nil); err != nil {
+		if c.auth.Type == "oauth1" && errors.Is(err, httpx.ErrUnauthorized) {
+			return fmt.Errorf("oauth1 tokens rejected (expired or revoked); re-run 'devflow auth jira oauth-setup' to reauthorize: %w", err)
+		}
+		return err
 	}
 	return nil
 }
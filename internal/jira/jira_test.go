@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/Ilia01/devflow/internal/config"
+	"github.com/Ilia01/devflow/internal/models"
 )
 
 func TestGetTicket(t *testing.T) {
@@ -54,6 +56,25 @@ func TestUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestListTransitions(t *testing.T) {
+	client := NewClient("https://example.com", "user@example.com", config.AuthMethod{Type: "personal_access_token", Token: "token"})
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		if req.Method != http.MethodGet || !strings.HasSuffix(req.URL.Path, "/transitions") {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		body := `{"transitions":[{"id":"1","name":"In Progress"},{"id":"2","name":"Done"}]}`
+		return jsonResponse(http.StatusOK, body)
+	})
+
+	names, err := client.ListTransitions("TEST-1")
+	if err != nil {
+		t.Fatalf("ListTransitions failed: %v", err)
+	}
+	if len(names) != 2 || names[0] != "In Progress" || names[1] != "Done" {
+		t.Fatalf("unexpected transitions: %v", names)
+	}
+}
+
 func TestSearchWithJQL(t *testing.T) {
 	client := NewClient("https://example.com", "user@example.com", config.AuthMethod{Type: "api_token", Token: "token"})
 	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
@@ -80,6 +101,63 @@ func TestSearchWithJQL(t *testing.T) {
 	}
 }
 
+func TestSearchWithJQLPage(t *testing.T) {
+	client := NewClient("https://example.com", "user@example.com", config.AuthMethod{Type: "api_token", Token: "token"})
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		var payload map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload["startAt"] != float64(50) || payload["maxResults"] != float64(50) {
+			t.Fatalf("unexpected paging params: startAt=%v maxResults=%v", payload["startAt"], payload["maxResults"])
+		}
+		body := `{"issues":[{"key":"TEST-51","fields":{"summary":"Page two","status":{"name":"Done"}}}],"startAt":50,"total":51}`
+		return jsonResponse(http.StatusOK, body)
+	})
+
+	page, err := client.SearchWithJQLPage(context.Background(), "project = TEST", 50, 50)
+	if err != nil {
+		t.Fatalf("SearchWithJQLPage failed: %v", err)
+	}
+	if page.Total != 51 || page.StartAt != 50 || len(page.Issues) != 1 || page.Issues[0].Key != "TEST-51" {
+		t.Fatalf("unexpected page: %#v", page)
+	}
+}
+
+func TestGetTicketWithFetchOptions(t *testing.T) {
+	client := NewClient("https://example.com", "user@example.com", config.AuthMethod{Type: "api_token", Token: "token"})
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		if req.URL.Query().Get("fields") != "summary,components" {
+			t.Fatalf("unexpected fields param: %s", req.URL.Query().Get("fields"))
+		}
+		if req.URL.Query().Get("expand") != "renderedFields" {
+			t.Fatalf("unexpected expand param: %s", req.URL.Query().Get("expand"))
+		}
+		body := `{"key":"TEST-1","fields":{"summary":"Test ticket","status":{"name":"To Do"}}}`
+		return jsonResponse(http.StatusOK, body)
+	})
+
+	if _, err := client.GetTicket("TEST-1", FetchOptions{Fields: []string{"summary", "components"}, Expand: []string{"renderedFields"}}); err != nil {
+		t.Fatalf("GetTicket failed: %v", err)
+	}
+}
+
+func TestFormatIssueLinks(t *testing.T) {
+	links := []models.JiraLink{
+		{
+			Type:         models.JiraLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+			OutwardIssue: &models.LinkedIssue{Key: "TEST-2", Fields: models.TicketFields{Summary: "Downstream work"}},
+		},
+		{Type: models.JiraLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"}},
+	}
+
+	got := FormatIssueLinks(links)
+	want := "- blocks: TEST-2 Downstream work"
+	if got != want {
+		t.Fatalf("FormatIssueLinks() = %q, want %q", got, want)
+	}
+}
+
 func TestTestConnection(t *testing.T) {
 	client := NewClient("https://example.com", "user@example.com", config.AuthMethod{Type: "api_token", Token: "token"})
 	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
@@ -0,0 +1,194 @@
+// Package jql builds JQL (Jira Query Language) clauses safely. It
+// replaces fragile string concatenation and strings.ReplaceAll-based
+// quoting: string literals are escaped properly, field names are
+// validated against a known set, and clauses compose via And/Or/Not
+// instead of manual " AND " joins.
+package jql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field is a known JQL field name.
+type Field string
+
+const (
+	FieldProject     Field = "project"
+	FieldAssignee    Field = "assignee"
+	FieldStatus      Field = "status"
+	FieldSummary     Field = "summary"
+	FieldDescription Field = "description"
+	FieldText        Field = "text"
+	FieldReporter    Field = "reporter"
+	FieldPriority    Field = "priority"
+	FieldLabels      Field = "labels"
+	FieldSprint      Field = "sprint"
+	FieldCreated     Field = "created"
+	FieldUpdated     Field = "updated"
+	FieldKey         Field = "key"
+)
+
+var knownFields = map[Field]bool{
+	FieldProject: true, FieldAssignee: true, FieldStatus: true, FieldSummary: true,
+	FieldDescription: true, FieldText: true, FieldReporter: true, FieldPriority: true,
+	FieldLabels: true, FieldSprint: true, FieldCreated: true, FieldUpdated: true, FieldKey: true,
+}
+
+// reservedWords can't be used as field names; they collide with JQL
+// keywords and would render an ambiguous or invalid query.
+var reservedWords = map[string]bool{
+	"and": true, "or": true, "not": true, "empty": true, "null": true,
+	"order": true, "by": true, "asc": true, "desc": true, "in": true, "is": true, "was": true,
+}
+
+func (f Field) validate() error {
+	lower := strings.ToLower(string(f))
+	if reservedWords[lower] {
+		return fmt.Errorf("jql: %q is a reserved word and cannot be used as a field", f)
+	}
+	if !knownFields[f] {
+		return fmt.Errorf("jql: unknown field %q", f)
+	}
+	return nil
+}
+
+// Clause is a composable, renderable fragment of a JQL query.
+type Clause interface {
+	Render() (string, error)
+}
+
+type clauseFunc func() (string, error)
+
+func (f clauseFunc) Render() (string, error) { return f() }
+
+// Raw is an escape hatch for JQL the builder doesn't model. It is
+// emitted verbatim, so the caller is responsible for quoting it.
+func Raw(jql string) Clause {
+	return clauseFunc(func() (string, error) { return jql, nil })
+}
+
+// quote escapes a string literal for JQL, covering both '"' and '\',
+// unlike the plain strings.ReplaceAll(value, `"`, `\"`) it replaces.
+func quote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// Eq renders `field = "value"`.
+func Eq(field Field, value string) Clause {
+	return clauseFunc(func() (string, error) {
+		if err := field.validate(); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s = %s", field, quote(value)), nil
+	})
+}
+
+// In renders `field in ("a", "b", ...)`.
+func In(field Field, values []string) Clause {
+	return clauseFunc(func() (string, error) {
+		if err := field.validate(); err != nil {
+			return "", err
+		}
+		if len(values) == 0 {
+			return "", fmt.Errorf("jql: In(%s) requires at least one value", field)
+		}
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = quote(v)
+		}
+		return fmt.Sprintf("%s in (%s)", field, strings.Join(quoted, ", ")), nil
+	})
+}
+
+// Like renders `field ~ "value"`.
+func Like(field Field, value string) Clause {
+	return clauseFunc(func() (string, error) {
+		if err := field.validate(); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s ~ %s", field, quote(value)), nil
+	})
+}
+
+func join(op string, clauses []Clause) Clause {
+	return clauseFunc(func() (string, error) {
+		if len(clauses) == 0 {
+			return "", fmt.Errorf("jql: %s requires at least one clause", op)
+		}
+		parts := make([]string, len(clauses))
+		for i, c := range clauses {
+			rendered, err := c.Render()
+			if err != nil {
+				return "", err
+			}
+			parts[i] = rendered
+		}
+		if len(parts) == 1 {
+			return parts[0], nil
+		}
+		return "(" + strings.Join(parts, " "+op+" ") + ")", nil
+	})
+}
+
+// And renders its clauses joined by AND, parenthesized when there is
+// more than one.
+func And(clauses ...Clause) Clause { return join("AND", clauses) }
+
+// Or renders its clauses joined by OR, parenthesized when there is more
+// than one.
+func Or(clauses ...Clause) Clause { return join("OR", clauses) }
+
+// Not renders the negation of a clause.
+func Not(clause Clause) Clause {
+	return clauseFunc(func() (string, error) {
+		rendered, err := clause.Render()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("NOT %s", rendered), nil
+	})
+}
+
+// OrderBy renders an `ORDER BY field ASC|DESC` fragment.
+func OrderBy(field Field, desc bool) Clause {
+	return clauseFunc(func() (string, error) {
+		if err := field.validate(); err != nil {
+			return "", err
+		}
+		dir := "ASC"
+		if desc {
+			dir = "DESC"
+		}
+		return fmt.Sprintf("ORDER BY %s %s", field, dir), nil
+	})
+}
+
+// Query is a full JQL statement: a filter clause plus an optional
+// OrderBy fragment.
+type Query struct {
+	Where Clause
+	Order Clause
+}
+
+// Render builds the final JQL string, validating and rendering Where and
+// Order in turn.
+func (q Query) Render() (string, error) {
+	var parts []string
+	if q.Where != nil {
+		where, err := q.Where.Render()
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, where)
+	}
+	if q.Order != nil {
+		order, err := q.Order.Render()
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, order)
+	}
+	return strings.Join(parts, " "), nil
+}
@@ -0,0 +1,108 @@
+package jql
+
+import "testing"
+
+func TestEqQuotesAndEscapes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "In Progress", `status = "In Progress"`},
+		{"quote", `say "hi"`, `status = "say \"hi\""`},
+		{"backslash", `C:\path`, `status = "C:\\path"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Eq(FieldStatus, tt.value).Render()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Eq() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqUnknownField(t *testing.T) {
+	if _, err := Eq(Field("bogus"), "x").Render(); err == nil {
+		t.Fatalf("expected error for unknown field")
+	}
+}
+
+func TestEqReservedWord(t *testing.T) {
+	if _, err := Eq(Field("AND"), "x").Render(); err == nil {
+		t.Fatalf("expected error for reserved word field")
+	}
+}
+
+func TestInRendersList(t *testing.T) {
+	got, err := In(FieldStatus, []string{"To Do", "In Progress"}).Render()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `status in ("To Do", "In Progress")`
+	if got != want {
+		t.Fatalf("In() = %s, want %s", got, want)
+	}
+}
+
+func TestInRequiresValues(t *testing.T) {
+	if _, err := In(FieldStatus, nil).Render(); err == nil {
+		t.Fatalf("expected error for empty value list")
+	}
+}
+
+func TestAndOrNot(t *testing.T) {
+	clause := And(
+		Eq(FieldProject, "WAB"),
+		Or(Eq(FieldAssignee, "me"), Eq(FieldAssignee, "jdoe")),
+		Not(Eq(FieldStatus, "Done")),
+	)
+
+	got, err := clause.Render()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `(project = "WAB" AND (assignee = "me" OR assignee = "jdoe") AND NOT status = "Done")`
+	if got != want {
+		t.Fatalf("And() = %s, want %s", got, want)
+	}
+}
+
+func TestAndSingleClauseUnparenthesized(t *testing.T) {
+	got, err := And(Eq(FieldProject, "WAB")).Render()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != `project = "WAB"` {
+		t.Fatalf("And() = %s, want unparenthesized single clause", got)
+	}
+}
+
+func TestQueryRenderWithOrderBy(t *testing.T) {
+	q := Query{
+		Where: And(Eq(FieldProject, "WAB"), Eq(FieldAssignee, "me")),
+		Order: OrderBy(FieldUpdated, true),
+	}
+	got, err := q.Render()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `(project = "WAB" AND assignee = "me") ORDER BY updated DESC`
+	if got != want {
+		t.Fatalf("Query.Render() = %s, want %s", got, want)
+	}
+}
+
+func TestRaw(t *testing.T) {
+	got, err := Raw(`assignee = currentUser()`).Render()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != `assignee = currentUser()` {
+		t.Fatalf("Raw() = %s", got)
+	}
+}
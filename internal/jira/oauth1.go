@@ -0,0 +1,243 @@
+package jira
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// oauth1Signer signs requests with RSA-SHA1 per OAuth 1.0a, the flavor JIRA's
+// application links use for its default consumer setup.
+type oauth1Signer struct {
+	consumerKey string
+	privateKey  *rsa.PrivateKey
+
+	accessToken string
+	tokenSecret string
+}
+
+func newOAuth1Signer(consumerKey, privateKeyPath, accessToken, tokenSecret string) (*oauth1Signer, error) {
+	key, err := loadRSAPrivateKey(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("load oauth1 private key: %w", err)
+	}
+	return &oauth1Signer{
+		consumerKey: consumerKey,
+		privateKey:  key,
+		accessToken: accessToken,
+		tokenSecret: tokenSecret,
+	}, nil
+}
+
+func loadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	generic, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+	key, ok := generic.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not RSA")
+	}
+	return key, nil
+}
+
+// sign builds the OAuth 1.0a Authorization header for req using RSA-SHA1.
+// It signs with the token passed in (no oauth_token when it is empty) and
+// never reads the signer's stored values, which lets the request-token and
+// access-token legs of the dance reuse the same signer before a long-lived
+// access token exists. tokenSecret is not used: RSA-SHA1 signs with the
+// private key alone.
+func (s *oauth1Signer) sign(req *http.Request, token, tokenSecret string) (string, error) {
+	params := map[string]string{
+		"oauth_consumer_key":     s.consumerKey,
+		"oauth_nonce":            oauthNonce(),
+		"oauth_signature_method": "RSA-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	if token != "" {
+		params["oauth_token"] = token
+	}
+
+	base := signatureBaseString(req.Method, req.URL, params)
+
+	hash := sha1.Sum([]byte(base))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA1, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("sign request: %w", err)
+	}
+	params["oauth_signature"] = base64.StdEncoding.EncodeToString(signature)
+
+	return authorizationHeader(params), nil
+}
+
+func signatureBaseString(method string, target *url.URL, params map[string]string) string {
+	normalized := *target
+	normalized.RawQuery = ""
+
+	all := map[string]string{}
+	for k, v := range params {
+		all[k] = v
+	}
+	for k, v := range target.Query() {
+		if len(v) > 0 {
+			all[k] = v[0]
+		}
+	}
+
+	keys := make([]string, 0, len(all))
+	for k := range all {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", percentEncode(k), percentEncode(all[k])))
+	}
+
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		percentEncode(normalized.String()),
+		percentEncode(strings.Join(pairs, "&")),
+	}, "&")
+}
+
+func authorizationHeader(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%q", k, percentEncode(params[k])))
+	}
+	return "OAuth " + strings.Join(parts, ", ")
+}
+
+func percentEncode(value string) string {
+	escaped := url.QueryEscape(value)
+	escaped = strings.ReplaceAll(escaped, "+", "%20")
+	return escaped
+}
+
+func oauthNonce() string {
+	n, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return n.String()
+}
+
+// RequestToken performs the first leg of the OAuth 1.0a dance: it obtains a
+// temporary request token from baseURL and returns the authorize URL the
+// user must visit to grant access.
+func RequestToken(baseURL, consumerKey, privateKeyPath string) (token, secret, authorizeURL string, err error) {
+	signer, err := newOAuth1Signer(consumerKey, privateKeyPath, "", "")
+	if err != nil {
+		return "", "", "", fmt.Errorf("load private key: %w", err)
+	}
+
+	endpoint := strings.TrimRight(baseURL, "/") + "/plugins/servlet/oauth/request-token"
+	req, err := http.NewRequest(http.MethodPost, endpoint, nil)
+	if err != nil {
+		return "", "", "", err
+	}
+	header, err := signer.sign(req, "", "")
+	if err != nil {
+		return "", "", "", err
+	}
+	req.Header.Set("Authorization", header)
+
+	values, err := doOAuthForm(req)
+	if err != nil {
+		return "", "", "", fmt.Errorf("request token: %w", err)
+	}
+
+	token = values.Get("oauth_token")
+	secret = values.Get("oauth_token_secret")
+	if token == "" {
+		return "", "", "", fmt.Errorf("jira did not return an oauth_token")
+	}
+
+	authorizeURL = fmt.Sprintf("%s/plugins/servlet/oauth/authorize?oauth_token=%s", strings.TrimRight(baseURL, "/"), url.QueryEscape(token))
+	return token, secret, authorizeURL, nil
+}
+
+// AccessToken performs the third leg of the OAuth 1.0a dance, swapping a
+// verified request token for a long-lived access token and secret.
+func AccessToken(baseURL, consumerKey, privateKeyPath, requestToken, requestSecret, verifier string) (accessToken, tokenSecret string, err error) {
+	signer, err := newOAuth1Signer(consumerKey, privateKeyPath, requestToken, requestSecret)
+	if err != nil {
+		return "", "", fmt.Errorf("load private key: %w", err)
+	}
+
+	endpoint := strings.TrimRight(baseURL, "/") + "/plugins/servlet/oauth/access-token"
+	req, err := http.NewRequest(http.MethodPost, endpoint+"?oauth_verifier="+url.QueryEscape(verifier), nil)
+	if err != nil {
+		return "", "", err
+	}
+	header, err := signer.sign(req, requestToken, requestSecret)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Authorization", header)
+
+	values, err := doOAuthForm(req)
+	if err != nil {
+		return "", "", fmt.Errorf("access token: %w", err)
+	}
+
+	accessToken = values.Get("oauth_token")
+	tokenSecret = values.Get("oauth_token_secret")
+	if accessToken == "" {
+		return "", "", fmt.Errorf("jira did not return an oauth_token")
+	}
+	return accessToken, tokenSecret, nil
+}
+
+func doOAuthForm(req *http.Request) (url.Values, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("http %d: %s", resp.StatusCode, string(body))
+	}
+
+	return url.ParseQuery(string(body))
+}
@@ -0,0 +1,256 @@
+// Package logging is a small leveled logger for devflow's background and
+// diagnostic output (sync progress, pipeline polling, --verbose traces) --
+// distinct from the colored step-by-step prose the wizard-style commands
+// (start, done, init) print directly via utils.Colorize. It composes on
+// top of utils' color primitives for its pretty, interactive Formatter,
+// and offers a plain and a JSON Formatter for redirected/CI output.
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ilia01/devflow/internal/utils"
+)
+
+// Level orders log lines by severity; a Logger only emits records at or
+// above its configured Level.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelNotice
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelNotice:
+		return "NOTICE"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	case LevelFatal:
+		return "FATAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Field is a single structured key=value pair attached to a log line.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// F builds a Field, e.g. logging.Info("pushed branch", logging.F("branch", name)).
+func F(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Record is one log line, handed to a Formatter.
+type Record struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  []Field
+}
+
+// Formatter renders a Record to a single line (no trailing newline).
+type Formatter interface {
+	Format(rec Record) string
+}
+
+var (
+	levelColorsMu sync.RWMutex
+	levelColors   = map[Level]string{
+		LevelDebug:  utils.ColorDim,
+		LevelInfo:   utils.ColorCyan,
+		LevelNotice: utils.ColorGreen,
+		LevelWarn:   utils.ColorYellow,
+		LevelError:  utils.ColorRed,
+		LevelFatal:  utils.ColorRed + utils.ColorBold,
+	}
+)
+
+// RegisterLevelColor changes the ANSI code PrettyFormatter uses for a
+// level's tag, for callers that want e.g. Warn to stand out as magenta.
+func RegisterLevelColor(level Level, code string) {
+	levelColorsMu.Lock()
+	defer levelColorsMu.Unlock()
+	levelColors[level] = code
+}
+
+func colorFor(level Level) string {
+	levelColorsMu.RLock()
+	defer levelColorsMu.RUnlock()
+	return levelColors[level]
+}
+
+func formatFields(fields []Field) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = fmt.Sprintf("%s=%v", field.Key, field.Value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// PrettyFormatter renders a colored level tag, a timestamp, the message,
+// and space-separated key=value fields. It is the default, interactive
+// formatter.
+type PrettyFormatter struct{}
+
+func (PrettyFormatter) Format(rec Record) string {
+	tag := utils.Colorize(fmt.Sprintf("[%s]", rec.Level), colorFor(rec.Level))
+	line := fmt.Sprintf("%s %s %s", rec.Time.Format("15:04:05"), tag, rec.Message)
+	if len(rec.Fields) > 0 {
+		line += " " + formatFields(rec.Fields)
+	}
+	return line
+}
+
+// PlainFormatter renders the same shape as PrettyFormatter with no ANSI
+// codes, for redirected output that should still read naturally.
+type PlainFormatter struct{}
+
+func (PlainFormatter) Format(rec Record) string {
+	line := fmt.Sprintf("%s [%s] %s", rec.Time.Format("15:04:05"), rec.Level, rec.Message)
+	if len(rec.Fields) > 0 {
+		line += " " + formatFields(rec.Fields)
+	}
+	return line
+}
+
+// JSONFormatter renders one JSON object per line, for CI log aggregators
+// and other machine consumers.
+type JSONFormatter struct{}
+
+func (JSONFormatter) Format(rec Record) string {
+	payload := map[string]any{
+		"time":    rec.Time.Format(time.RFC3339),
+		"level":   rec.Level.String(),
+		"message": rec.Message,
+	}
+	for _, field := range rec.Fields {
+		payload[field.Key] = field.Value
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Sprintf(`{"level":"ERROR","message":"logging: marshal record: %s"}`, err)
+	}
+	return string(data)
+}
+
+// Logger writes leveled, formatted records to an io.Writer. The zero value
+// is not usable; construct one with New.
+type Logger struct {
+	mu        sync.Mutex
+	out       io.Writer
+	level     Level
+	formatter Formatter
+}
+
+// New builds a Logger writing to w at LevelInfo using PrettyFormatter.
+func New(w io.Writer) *Logger {
+	return &Logger{out: w, level: LevelInfo, formatter: PrettyFormatter{}}
+}
+
+func (l *Logger) SetLevel(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+}
+
+func (l *Logger) SetFormatter(f Formatter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.formatter = f
+}
+
+// SetOutput redirects the logger to w and returns the previous writer.
+func (l *Logger) SetOutput(w io.Writer) io.Writer {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	prev := l.out
+	l.out = w
+	return prev
+}
+
+func (l *Logger) log(level Level, message string, fields []Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if level < l.level {
+		return
+	}
+	line := l.formatter.Format(Record{Time: time.Now(), Level: level, Message: message, Fields: fields})
+	fmt.Fprintln(l.out, line)
+}
+
+func (l *Logger) Debug(message string, fields ...Field)  { l.log(LevelDebug, message, fields) }
+func (l *Logger) Info(message string, fields ...Field)   { l.log(LevelInfo, message, fields) }
+func (l *Logger) Notice(message string, fields ...Field) { l.log(LevelNotice, message, fields) }
+func (l *Logger) Warn(message string, fields ...Field)   { l.log(LevelWarn, message, fields) }
+func (l *Logger) Error(message string, fields ...Field)  { l.log(LevelError, message, fields) }
+
+// Fatal logs at LevelFatal and then exits the process with status 1.
+func (l *Logger) Fatal(message string, fields ...Field) {
+	l.log(LevelFatal, message, fields)
+	os.Exit(1)
+}
+
+// Writer returns an io.Writer that logs each line written to it at level,
+// for streaming long-running operation output (pipeline polling, importer
+// progress) through the logger instead of writing straight to stdout.
+func (l *Logger) Writer(level Level) io.Writer {
+	return &lineWriter{logger: l, level: level}
+}
+
+type lineWriter struct {
+	logger *Logger
+	level  Level
+	buf    []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		line := strings.TrimRight(string(w.buf[:idx]), "\r")
+		w.logger.log(w.level, line, nil)
+		w.buf = w.buf[idx+1:]
+	}
+	return len(p), nil
+}
+
+// std is the package-level logger the top-level functions below delegate
+// to, mirroring how utils.Colorize defaults to stdout.
+var std = New(os.Stdout)
+
+func SetLevel(level Level)            { std.SetLevel(level) }
+func SetFormatter(f Formatter)        { std.SetFormatter(f) }
+func SetOutput(w io.Writer) io.Writer { return std.SetOutput(w) }
+func Writer(level Level) io.Writer    { return std.Writer(level) }
+
+func Debug(message string, fields ...Field)  { std.Debug(message, fields...) }
+func Info(message string, fields ...Field)   { std.Info(message, fields...) }
+func Notice(message string, fields ...Field) { std.Notice(message, fields...) }
+func Warn(message string, fields ...Field)   { std.Warn(message, fields...) }
+func Error(message string, fields ...Field)  { std.Error(message, fields...) }
+func Fatal(message string, fields ...Field)  { std.Fatal(message, fields...) }
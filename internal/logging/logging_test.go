@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLoggerFiltersBelowConfiguredLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+	logger.SetFormatter(PlainFormatter{})
+	logger.SetLevel(LevelWarn)
+
+	logger.Info("should be dropped")
+	logger.Warn("should appear")
+
+	out := buf.String()
+	if strings.Contains(out, "should be dropped") {
+		t.Fatalf("Info line should have been filtered out: %q", out)
+	}
+	if !strings.Contains(out, "should appear") {
+		t.Fatalf("Warn line missing: %q", out)
+	}
+}
+
+func TestPlainFormatterIncludesFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+	logger.SetFormatter(PlainFormatter{})
+
+	logger.Info("synced", F("count", 3), F("project", "WAB"))
+
+	out := buf.String()
+	if !strings.Contains(out, "count=3") || !strings.Contains(out, "project=WAB") {
+		t.Fatalf("fields missing from output: %q", out)
+	}
+	if strings.Contains(out, "\033[") {
+		t.Fatalf("PlainFormatter should not emit ANSI codes: %q", out)
+	}
+}
+
+func TestJSONFormatterProducesParseableLines(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+	logger.SetFormatter(JSONFormatter{})
+
+	logger.Error("export failed", F("ticket", "WAB-1"))
+
+	out := strings.TrimSpace(buf.String())
+	if !strings.HasPrefix(out, "{") || !strings.HasSuffix(out, "}") {
+		t.Fatalf("expected a single JSON object line, got %q", out)
+	}
+	if !strings.Contains(out, `"ticket":"WAB-1"`) {
+		t.Fatalf("expected ticket field in JSON output: %q", out)
+	}
+}
+
+func TestWriterSplitsLinesAtConfiguredLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+	logger.SetFormatter(PlainFormatter{})
+	logger.SetLevel(LevelDebug)
+
+	w := logger.Writer(LevelDebug)
+	w.Write([]byte("first line\nsecond"))
+	w.Write([]byte(" line\n"))
+
+	out := buf.String()
+	if !strings.Contains(out, "first line") || !strings.Contains(out, "second line") {
+		t.Fatalf("expected both lines logged: %q", out)
+	}
+	if strings.Count(out, "\n") != 2 {
+		t.Fatalf("expected exactly two log lines, got: %q", out)
+	}
+}
@@ -1,5 +1,46 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// jiraTimeLayout matches the timestamp format Jira's REST API actually
+// returns, e.g. "2024-03-15T10:30:00.000+0000" -- a zone offset with no
+// colon, which time.Time's default RFC3339 UnmarshalJSON rejects.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// JiraTime decodes Jira's timestamp fields (issue "updated", comment
+// "created", ...). It embeds time.Time so callers keep using After, Format,
+// IsZero, etc. directly.
+type JiraTime struct {
+	time.Time
+}
+
+func (t *JiraTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		return nil
+	}
+	parsed, err := time.Parse(jiraTimeLayout, s)
+	if err != nil {
+		return fmt.Errorf("parse jira timestamp %q: %w", s, err)
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t JiraTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return json.Marshal("")
+	}
+	return json.Marshal(t.Format(jiraTimeLayout))
+}
+
 type JiraTicket struct {
 	Key    string       `json:"key"`
 	Fields TicketFields `json:"fields"`
@@ -10,6 +51,21 @@ type TicketFields struct {
 	Description string       `json:"description"`
 	Status      TicketStatus `json:"status"`
 	Assignee    *TicketUser  `json:"assignee"`
+	Updated     JiraTime     `json:"updated"`
+	Parent      *LinkedIssue `json:"parent,omitempty"`
+	IssueLinks  []JiraLink   `json:"issuelinks,omitempty"`
+
+	Labels     []string        `json:"labels,omitempty"`
+	Components []JiraComponent `json:"components,omitempty"`
+	Sprint     *JiraSprint     `json:"sprint,omitempty"`
+	Priority   *TicketPriority `json:"priority,omitempty"`
+	IssueType  TicketIssueType `json:"issuetype,omitempty"`
+
+	// DescriptionADF carries the Atlassian Document Format rendering of
+	// Description, requested via FetchOptions.Expand, for callers (PR
+	// description rendering) that want Jira's own rich-text blocks rather
+	// than the flattened plain-text Description.
+	DescriptionADF json.RawMessage `json:"descriptionAdf,omitempty"`
 }
 
 type TicketStatus struct {
@@ -19,3 +75,48 @@ type TicketStatus struct {
 type TicketUser struct {
 	DisplayName string `json:"displayName"`
 }
+
+type JiraComponent struct {
+	Name string `json:"name"`
+}
+
+type JiraSprint struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+type TicketPriority struct {
+	Name string `json:"name"`
+}
+
+type TicketIssueType struct {
+	Name string `json:"name"`
+}
+
+type JiraComment struct {
+	ID      string     `json:"id"`
+	Author  TicketUser `json:"author"`
+	Body    string     `json:"body"`
+	Created JiraTime   `json:"created"`
+}
+
+// LinkedIssue is the slim issue representation Jira embeds in issue links
+// and parent references; Jira fills in only a few of its Fields (such as
+// summary and status), so the rest are left at their zero values.
+type LinkedIssue struct {
+	Key    string       `json:"key"`
+	Fields TicketFields `json:"fields"`
+}
+
+type JiraLinkType struct {
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+type JiraLink struct {
+	ID           string       `json:"id"`
+	Type         JiraLinkType `json:"type"`
+	InwardIssue  *LinkedIssue `json:"inwardIssue,omitempty"`
+	OutwardIssue *LinkedIssue `json:"outwardIssue,omitempty"`
+}
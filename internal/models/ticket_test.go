@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTicketFieldsUnmarshalsJiraTimestampFormat(t *testing.T) {
+	data := []byte(`{"summary":"x","status":{"name":"Open"},"assignee":null,"updated":"2024-03-15T10:30:00.000+0000"}`)
+
+	var fields TicketFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	want := time.Date(2024, 3, 15, 10, 30, 0, 0, time.FixedZone("", 0))
+	if !fields.Updated.Equal(want) {
+		t.Fatalf("got %v, want %v", fields.Updated, want)
+	}
+}
+
+func TestJiraCommentUnmarshalsJiraTimestampFormat(t *testing.T) {
+	data := []byte(`{"id":"1","author":{"displayName":"x"},"body":"hi","created":"2024-03-15T10:30:00.000+0000"}`)
+
+	var comment JiraComment
+	if err := json.Unmarshal(data, &comment); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if comment.Created.IsZero() {
+		t.Fatal("expected Created to be parsed, got zero time")
+	}
+}
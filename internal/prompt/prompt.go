@@ -0,0 +1,268 @@
+// Package prompt implements the interactive input subsystem shared by
+// devflow's setup and selection flows (init, profile add, ticket
+// selection). It is modeled on git-bug's input/prompt.go: a validator
+// loop that re-asks on complaint, a NoConfirm escape hatch for scripted
+// use (mirroring yay's config.NoConfirm), and non-TTY detection so piped
+// input is read line-by-line instead of blocking on a terminal prompt.
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/Ilia01/devflow/internal/utils"
+)
+
+// NoConfirm auto-accepts the default for every prompt instead of
+// blocking on input. It is normally wired to the --no-confirm global
+// flag so scripts can drive devflow non-interactively.
+var NoConfirm bool
+
+// Validator inspects a candidate value before it is accepted. A
+// non-empty complaint re-asks the question; a non-nil err aborts the
+// prompt loop entirely.
+type Validator func(name, value string) (complaint string, err error)
+
+// Required rejects an empty value.
+func Required(name, value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Sprintf("%s is required", name), nil
+	}
+	return "", nil
+}
+
+// IsURL rejects a value that doesn't parse as an absolute URL. Empty
+// values pass through so it composes with an optional field.
+func IsURL(name, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Sprintf("%s must be a valid URL (e.g. https://example.com)", name), nil
+	}
+	return "", nil
+}
+
+// IsEmail rejects a value that doesn't look like an email address.
+func IsEmail(name, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	at := strings.Index(value, "@")
+	if at <= 0 || at == len(value)-1 || strings.Contains(value[at+1:], "@") {
+		return fmt.Sprintf("%s must be a valid email address", name), nil
+	}
+	return "", nil
+}
+
+// IsInt rejects a value that isn't a whole number.
+func IsInt(name, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if _, err := strconv.Atoi(value); err != nil {
+		return fmt.Sprintf("%s must be a whole number", name), nil
+	}
+	return "", nil
+}
+
+// InRange rejects an integer value outside [min, max]. It implies IsInt.
+func InRange(min, max int) Validator {
+	return func(name, value string) (string, error) {
+		if value == "" {
+			return "", nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Sprintf("%s must be a whole number", name), nil
+		}
+		if n < min || n > max {
+			return fmt.Sprintf("%s must be between %d and %d", name, min, max), nil
+		}
+		return "", nil
+	}
+}
+
+// Prompt asks for a value with no default. On a terminal it re-asks until
+// every validator is satisfied; under NoConfirm or with piped stdin the
+// value is returned without running the validators.
+func Prompt(message string, validators ...Validator) (string, error) {
+	return ask(message, "", true, validators...)
+}
+
+// PromptDefault asks for a value, falling back to defaultValue when the
+// user presses Enter (or when NoConfirm is set, or piped input gives an
+// empty line or ends).
+func PromptDefault(message, defaultValue string, validators ...Validator) (string, error) {
+	return ask(message, defaultValue, true, validators...)
+}
+
+// PromptChoice asks for one of choices, falling back to defaultChoice.
+func PromptChoice(message string, choices []string, defaultChoice string) (string, error) {
+	oneOf := func(name, value string) (string, error) {
+		for _, c := range choices {
+			if strings.EqualFold(c, value) {
+				return "", nil
+			}
+		}
+		return fmt.Sprintf("%s must be one of: %s", name, strings.Join(choices, ", ")), nil
+	}
+	return ask(fmt.Sprintf("%s (%s)", message, strings.Join(choices, "/")), defaultChoice, true, oneOf)
+}
+
+// PromptPassword asks for a value without echoing it to the terminal.
+func PromptPassword(message string, validators ...Validator) (string, error) {
+	return ask(message, "", false, validators...)
+}
+
+// PromptSecretWithConfirm asks for a value twice without echoing it, and
+// fails if the two entries don't match, so a mistyped token during
+// `devflow auth login` is caught immediately instead of surfacing
+// later as an opaque 401. It skips the second read under NoConfirm or
+// piped stdin, where there's no human re-entering anything to compare.
+func PromptSecretWithConfirm(message string, validators ...Validator) (string, error) {
+	value, err := PromptPassword(message, validators...)
+	if err != nil {
+		return "", err
+	}
+	if NoConfirm || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return value, nil
+	}
+
+	confirm, err := PromptPassword(fmt.Sprintf("Confirm %s", message))
+	if err != nil {
+		return "", err
+	}
+	if confirm != value {
+		return "", fmt.Errorf("%s entries did not match", message)
+	}
+	return value, nil
+}
+
+func ask(message, defaultValue string, echo bool, validators ...Validator) (string, error) {
+	if NoConfirm {
+		return defaultValue, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return readPiped(defaultValue)
+	}
+
+	restore := catchInterrupt(int(os.Stdin.Fd()))
+	defer restore()
+
+	for {
+		value, err := readLine(message, defaultValue, echo)
+		if err != nil {
+			return "", err
+		}
+		if value == "" {
+			value = defaultValue
+		}
+
+		complaint := ""
+		for _, v := range validators {
+			complaint, err = v(message, value)
+			if err != nil {
+				return "", err
+			}
+			if complaint != "" {
+				break
+			}
+		}
+		if complaint == "" {
+			return value, nil
+		}
+		fmt.Println(utils.Red(complaint))
+	}
+}
+
+func readLine(message, defaultValue string, echo bool) (string, error) {
+	if !echo {
+		fmt.Printf("%s: ", utils.BrightWhite(message))
+		data, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", utils.BrightWhite(message), utils.Dim(defaultValue))
+	} else {
+		fmt.Printf("%s: ", utils.BrightWhite(message))
+	}
+	reader := bufio.NewReader(os.Stdin)
+	text, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(text), nil
+}
+
+// pipedScanner and pipedScannerStdin back readPiped: a bufio.Scanner reads
+// ahead and buffers whatever's available on the pipe, so a fresh scanner
+// per call discards every line past the first one it reads. Reusing a
+// single scanner across prompts lets later fields in the same
+// non-interactive run (e.g. a multi-field `devflow init` fed via a
+// pipe) see the input meant for them. The cached scanner is rebuilt if
+// os.Stdin itself changes (tests swap it out per case).
+var (
+	pipedScanner      *bufio.Scanner
+	pipedScannerStdin *os.File
+)
+
+// readPiped reads a single line without terminal decoration, for
+// non-interactive stdin (scripts, CI). EOF falls back to defaultValue so
+// a truncated pipe behaves like an accepted default rather than an error.
+func readPiped(defaultValue string) (string, error) {
+	if pipedScanner == nil || pipedScannerStdin != os.Stdin {
+		pipedScanner = bufio.NewScanner(os.Stdin)
+		pipedScannerStdin = os.Stdin
+	}
+	if !pipedScanner.Scan() {
+		return defaultValue, nil
+	}
+	line := strings.TrimSpace(pipedScanner.Text())
+	if line == "" {
+		return defaultValue, nil
+	}
+	return line, nil
+}
+
+// catchInterrupt restores the terminal's echo/raw state on SIGINT so a
+// Ctrl-C during PromptPassword doesn't leave the shell without echo.
+// It returns a cleanup func that must be deferred by the caller.
+func catchInterrupt(fd int) func() {
+	state, err := term.GetState(fd)
+	if err != nil {
+		return func() {}
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-sigCh:
+			_ = term.Restore(fd, state)
+			fmt.Println()
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		signal.Stop(sigCh)
+	}
+}
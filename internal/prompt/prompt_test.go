@@ -0,0 +1,137 @@
+package prompt
+
+import (
+	"os"
+	"testing"
+)
+
+// withPipedStdin fakes stdin's FD with an *os.File pipe so term.IsTerminal
+// reports false, driving the readPiped fallback instead of blocking on a
+// real terminal read. It writes lines (each given its own "\n") and
+// restores the original os.Stdin on cleanup.
+func withPipedStdin(t *testing.T, lines ...string) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+
+	original := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = original })
+
+	go func() {
+		for _, line := range lines {
+			_, _ = w.WriteString(line + "\n")
+		}
+		w.Close()
+	}()
+}
+
+func TestPromptPasswordReadsPipedStdin(t *testing.T) {
+	withPipedStdin(t, "s3cret")
+
+	got, err := PromptPassword("Token")
+	if err != nil {
+		t.Fatalf("PromptPassword: %v", err)
+	}
+	if got != "s3cret" {
+		t.Fatalf("got %q, want %q", got, "s3cret")
+	}
+}
+
+func TestPromptSecretWithConfirmSkipsSecondReadWhenPiped(t *testing.T) {
+	withPipedStdin(t, "only-one-line")
+
+	got, err := PromptSecretWithConfirm("Token")
+	if err != nil {
+		t.Fatalf("PromptSecretWithConfirm: %v", err)
+	}
+	if got != "only-one-line" {
+		t.Fatalf("got %q, want %q", got, "only-one-line")
+	}
+}
+
+func TestMultipleFieldPromptsShareThePipedScanner(t *testing.T) {
+	withPipedStdin(t, "a", "b", "c")
+
+	for _, want := range []string{"a", "b", "c"} {
+		got, err := Prompt("field")
+		if err != nil {
+			t.Fatalf("Prompt: %v", err)
+		}
+		if got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestRequired(t *testing.T) {
+	if complaint, _ := Required("Jira URL", ""); complaint == "" {
+		t.Fatalf("expected complaint for empty value")
+	}
+	if complaint, _ := Required("Jira URL", "https://jira"); complaint != "" {
+		t.Fatalf("unexpected complaint: %s", complaint)
+	}
+}
+
+func TestIsURL(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"", false},
+		{"https://jira.example.com", false},
+		{"not a url", true},
+		{"ftp:///missing-host", true},
+	}
+	for _, tt := range tests {
+		complaint, err := IsURL("URL", tt.value)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if (complaint != "") != tt.wantErr {
+			t.Fatalf("IsURL(%q) complaint=%q, wantErr=%v", tt.value, complaint, tt.wantErr)
+		}
+	}
+}
+
+func TestIsEmail(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"", false},
+		{"dev@example.com", false},
+		{"not-an-email", true},
+		{"@example.com", true},
+		{"dev@", true},
+	}
+	for _, tt := range tests {
+		complaint, err := IsEmail("email", tt.value)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if (complaint != "") != tt.wantErr {
+			t.Fatalf("IsEmail(%q) complaint=%q, wantErr=%v", tt.value, complaint, tt.wantErr)
+		}
+	}
+}
+
+func TestInRange(t *testing.T) {
+	validator := InRange(1, 3)
+
+	if complaint, _ := validator("choice", ""); complaint != "" {
+		t.Fatalf("empty value should pass through: %s", complaint)
+	}
+	if complaint, _ := validator("choice", "2"); complaint != "" {
+		t.Fatalf("unexpected complaint: %s", complaint)
+	}
+	if complaint, _ := validator("choice", "7"); complaint == "" {
+		t.Fatalf("expected complaint for out-of-range value")
+	}
+	if complaint, _ := validator("choice", "abc"); complaint == "" {
+		t.Fatalf("expected complaint for non-integer value")
+	}
+}
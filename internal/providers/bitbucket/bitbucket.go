@@ -0,0 +1,192 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Ilia01/devflow/internal/httpx"
+	"github.com/Ilia01/devflow/internal/providers"
+)
+
+func init() {
+	providers.Register("bitbucket", func(cfg providers.Config) providers.Provider {
+		return NewClient(cfg.Owner, cfg.Repo, cfg.Token)
+	})
+}
+
+// Client talks to Bitbucket Cloud. Owner here is the workspace slug.
+type Client struct {
+	workspace string
+	repo      string
+	token     string
+	http      *http.Client
+	retrier   *httpx.Client
+	baseURL   string
+}
+
+func NewClient(workspace, repo, token string) *Client {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	retrier := httpx.NewClient(httpClient)
+	retrier.Auth = func(req *http.Request) error {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Content-Type", "application/json")
+		return nil
+	}
+	return &Client{
+		workspace: workspace,
+		repo:      repo,
+		token:     token,
+		http:      httpClient,
+		retrier:   retrier,
+		baseURL:   "https://api.bitbucket.org",
+	}
+}
+
+func (c *Client) CreatePullRequest(sourceBranch, targetBranch, title, description string) (string, error) {
+	payload := map[string]any{
+		"title":       title,
+		"description": description,
+		"source":      map[string]any{"branch": map[string]string{"name": sourceBranch}},
+		"destination": map[string]any{"branch": map[string]string{"name": targetBranch}},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	apiURL := fmt.Sprintf("%s/2.0/repositories/%s/%s/pullrequests", c.baseURL, c.workspace, c.repo)
+	var result struct {
+		Links struct {
+			HTML struct {
+				Href string `json:"href"`
+			} `json:"html"`
+		} `json:"links"`
+	}
+	if err := c.doJSON(http.MethodPost, apiURL, bytes.NewReader(body), &result); err != nil {
+		return "", err
+	}
+	return result.Links.HTML.Href, nil
+}
+
+// GetPullRequest looks up a single PR by its numeric ID (passed as a
+// string to satisfy the provider-agnostic interface).
+func (c *Client) GetPullRequest(id string) (*providers.PullRequest, error) {
+	var pr bitbucketPullRequest
+	apiURL := fmt.Sprintf("%s/2.0/repositories/%s/%s/pullrequests/%s", c.baseURL, c.workspace, c.repo, id)
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &pr); err != nil {
+		return nil, err
+	}
+	return pr.toPullRequest(), nil
+}
+
+// ListOpenPullRequests returns the repository's currently open PRs.
+func (c *Client) ListOpenPullRequests() ([]providers.PullRequest, error) {
+	var page struct {
+		Values []bitbucketPullRequest `json:"values"`
+	}
+	apiURL := fmt.Sprintf("%s/2.0/repositories/%s/%s/pullrequests?state=OPEN", c.baseURL, c.workspace, c.repo)
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &page); err != nil {
+		return nil, err
+	}
+	result := make([]providers.PullRequest, 0, len(page.Values))
+	for _, pr := range page.Values {
+		result = append(result, *pr.toPullRequest())
+	}
+	return result, nil
+}
+
+// TestConnection checks that the configured token can see the repository.
+func (c *Client) TestConnection() error {
+	apiURL := fmt.Sprintf("%s/2.0/repositories/%s/%s", c.baseURL, c.workspace, c.repo)
+	return c.doJSON(http.MethodGet, apiURL, nil, nil)
+}
+
+// AddReviewers sets the reviewers on PR id, identifying each by Bitbucket
+// username.
+func (c *Client) AddReviewers(id string, reviewers []string) error {
+	type reviewer struct {
+		Username string `json:"username"`
+	}
+	names := make([]reviewer, 0, len(reviewers))
+	for _, r := range reviewers {
+		names = append(names, reviewer{Username: r})
+	}
+	payload := map[string]any{"reviewers": names}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	apiURL := fmt.Sprintf("%s/2.0/repositories/%s/%s/pullrequests/%s", c.baseURL, c.workspace, c.repo, id)
+	return c.doJSON(http.MethodPut, apiURL, bytes.NewReader(body), nil)
+}
+
+// MergePullRequest merges PR id.
+func (c *Client) MergePullRequest(id string) error {
+	apiURL := fmt.Sprintf("%s/2.0/repositories/%s/%s/pullrequests/%s/merge", c.baseURL, c.workspace, c.repo, id)
+	return c.doJSON(http.MethodPost, apiURL, nil, nil)
+}
+
+type bitbucketPullRequest struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	State string `json:"state"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+	Source struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"source"`
+	Destination struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"destination"`
+}
+
+func (pr bitbucketPullRequest) toPullRequest() *providers.PullRequest {
+	return &providers.PullRequest{
+		ID:     strconv.Itoa(pr.ID),
+		Title:  pr.Title,
+		URL:    pr.Links.HTML.Href,
+		Source: pr.Source.Branch.Name,
+		Target: pr.Destination.Branch.Name,
+		State:  pr.State,
+	}
+}
+
+// doJSON performs an authenticated, retried request and, if out is
+// non-nil, unmarshals the response body into it. Non-2xx responses come
+// back as a *httpx.APIError wrapping httpx.ErrUnauthorized/
+// ErrNotFound/ErrConflict/ErrRateLimited.
+func (c *Client) doJSON(method, apiURL string, body io.Reader, out any) error {
+	req, err := c.retrier.NewRequest(method, apiURL, body)
+	if err != nil {
+		return err
+	}
+	return c.retrier.DoJSON(req, out)
+}
+
+func (c *Client) Name() string { return "bitbucket" }
+
+func (c *Client) Validate() error {
+	if c.workspace == "" || c.repo == "" {
+		return errors.New("Bitbucket workspace/repo not configured")
+	}
+	return nil
+}
+
+func (c *Client) PullRequestURL(branch string) string {
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/pull-requests/?state=OPEN&source_branch=%s",
+		c.workspace, c.repo, url.QueryEscape(branch))
+}
@@ -0,0 +1,175 @@
+package gitea
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ilia01/devflow/internal/httpx"
+	"github.com/Ilia01/devflow/internal/providers"
+)
+
+func init() {
+	providers.Register("gitea", func(cfg providers.Config) providers.Provider {
+		return NewClient(cfg.BaseURL, cfg.Owner, cfg.Repo, cfg.Token)
+	})
+}
+
+type Client struct {
+	baseURL string
+	owner   string
+	repo    string
+	token   string
+	http    *http.Client
+	retrier *httpx.Client
+}
+
+func NewClient(baseURL, owner, repo, token string) *Client {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	retrier := httpx.NewClient(httpClient)
+	retrier.Auth = func(req *http.Request) error {
+		req.Header.Set("Authorization", fmt.Sprintf("token %s", token))
+		req.Header.Set("Content-Type", "application/json")
+		return nil
+	}
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		owner:   owner,
+		repo:    repo,
+		token:   token,
+		http:    httpClient,
+		retrier: retrier,
+	}
+}
+
+func (c *Client) CreatePullRequest(sourceBranch, targetBranch, title, description string) (string, error) {
+	payload := map[string]string{
+		"title": title,
+		"body":  description,
+		"head":  sourceBranch,
+		"base":  targetBranch,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/%s/pulls", c.baseURL, c.owner, c.repo)
+	var result struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := c.doJSON(http.MethodPost, apiURL, bytes.NewReader(body), &result); err != nil {
+		return "", err
+	}
+	return result.HTMLURL, nil
+}
+
+// GetPullRequest looks up a single PR by its index (passed as a string
+// to satisfy the provider-agnostic interface).
+func (c *Client) GetPullRequest(id string) (*providers.PullRequest, error) {
+	var pr giteaPullRequest
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/%s/pulls/%s", c.baseURL, c.owner, c.repo, id)
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &pr); err != nil {
+		return nil, err
+	}
+	return pr.toPullRequest(), nil
+}
+
+// ListOpenPullRequests returns the repository's currently open PRs.
+func (c *Client) ListOpenPullRequests() ([]providers.PullRequest, error) {
+	var prs []giteaPullRequest
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/%s/pulls?state=open", c.baseURL, c.owner, c.repo)
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &prs); err != nil {
+		return nil, err
+	}
+	result := make([]providers.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, *pr.toPullRequest())
+	}
+	return result, nil
+}
+
+// TestConnection checks that the configured token can see the repository.
+func (c *Client) TestConnection() error {
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/%s", c.baseURL, c.owner, c.repo)
+	return c.doJSON(http.MethodGet, apiURL, nil, nil)
+}
+
+// AddReviewers requests review from the given Gitea usernames on PR id.
+func (c *Client) AddReviewers(id string, reviewers []string) error {
+	payload := map[string][]string{"reviewers": reviewers}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/%s/pulls/%s/requested_reviewers", c.baseURL, c.owner, c.repo, id)
+	return c.doJSON(http.MethodPost, apiURL, bytes.NewReader(body), nil)
+}
+
+// MergePullRequest merges PR id using Gitea's default merge style.
+func (c *Client) MergePullRequest(id string) error {
+	payload := map[string]string{"Do": "merge"}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/%s/pulls/%s/merge", c.baseURL, c.owner, c.repo, id)
+	return c.doJSON(http.MethodPost, apiURL, bytes.NewReader(body), nil)
+}
+
+type giteaPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (pr giteaPullRequest) toPullRequest() *providers.PullRequest {
+	return &providers.PullRequest{
+		ID:     strconv.Itoa(pr.Number),
+		Title:  pr.Title,
+		URL:    pr.HTMLURL,
+		Source: pr.Head.Ref,
+		Target: pr.Base.Ref,
+		State:  pr.State,
+	}
+}
+
+// doJSON performs an authenticated, retried request and, if out is
+// non-nil, unmarshals the response body into it. Non-2xx responses come
+// back as a *httpx.APIError wrapping httpx.ErrUnauthorized/
+// ErrNotFound/ErrConflict/ErrRateLimited.
+func (c *Client) doJSON(method, apiURL string, body io.Reader, out any) error {
+	req, err := c.retrier.NewRequest(method, apiURL, body)
+	if err != nil {
+		return err
+	}
+	return c.retrier.DoJSON(req, out)
+}
+
+func (c *Client) Name() string { return "gitea" }
+
+func (c *Client) Validate() error {
+	if c.owner == "" || c.repo == "" {
+		return errors.New("Gitea owner/repo not configured")
+	}
+	return nil
+}
+
+func (c *Client) PullRequestURL(branch string) string {
+	return fmt.Sprintf("%s/%s/%s/pulls?q=&type=all&state=open&poster=&label_ids=&milestone=0&project=0&assignee=0&head=%s",
+		c.baseURL, c.owner, c.repo, url.QueryEscape(branch))
+}
@@ -3,26 +3,49 @@ package github
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/Ilia01/devflow/internal/httpx"
+	"github.com/Ilia01/devflow/internal/providers"
 )
 
+func init() {
+	providers.Register("github", func(cfg providers.Config) providers.Provider {
+		return NewClient(cfg.Owner, cfg.Repo, cfg.Token)
+	})
+}
+
 type Client struct {
 	owner   string
 	repo    string
 	token   string
 	http    *http.Client
+	retrier *httpx.Client
 	baseURL string
 }
 
 func NewClient(owner, repo, token string) *Client {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	retrier := httpx.NewClient(httpClient)
+	retrier.Auth = func(req *http.Request) error {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("User-Agent", "devflow-cli")
+		return nil
+	}
 	return &Client{
 		owner:   owner,
 		repo:    repo,
 		token:   token,
-		http:    &http.Client{Timeout: 30 * time.Second},
+		http:    httpClient,
+		retrier: retrier,
 		baseURL: "https://api.github.com",
 	}
 }
@@ -39,35 +62,158 @@ func (c *Client) CreatePullRequest(sourceBranch, targetBranch, title, descriptio
 		return "", err
 	}
 
-	url := fmt.Sprintf("%s/repos/%s/%s/pulls", c.baseURL, c.owner, c.repo)
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
-	if err != nil {
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/pulls", c.baseURL, c.owner, c.repo)
+	var result struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := c.doJSON(http.MethodPost, apiURL, bytes.NewReader(body), &result); err != nil {
 		return "", err
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("User-Agent", "devflow-cli")
+	return result.HTMLURL, nil
+}
 
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return "", err
+// GetPullRequest looks up a single PR by its number (passed as a string
+// to satisfy the provider-agnostic interface).
+func (c *Client) GetPullRequest(id string) (*providers.PullRequest, error) {
+	var pr githubPullRequest
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/pulls/%s", c.baseURL, c.owner, c.repo, id)
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &pr); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return pr.toPullRequest(), nil
+}
 
-	data, err := io.ReadAll(resp.Body)
+// ListOpenPullRequests returns the repository's currently open PRs.
+func (c *Client) ListOpenPullRequests() ([]providers.PullRequest, error) {
+	var prs []githubPullRequest
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/pulls?state=open", c.baseURL, c.owner, c.repo)
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &prs); err != nil {
+		return nil, err
+	}
+	result := make([]providers.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, *pr.toPullRequest())
+	}
+	return result, nil
+}
+
+// TestConnection checks that the configured token can see the repository.
+func (c *Client) TestConnection() error {
+	apiURL := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, c.owner, c.repo)
+	return c.doJSON(http.MethodGet, apiURL, nil, nil)
+}
+
+// AddReviewers requests review from the given GitHub usernames on PR id.
+func (c *Client) AddReviewers(id string, reviewers []string) error {
+	payload := map[string][]string{"reviewers": reviewers}
+	body, err := json.Marshal(payload)
 	if err != nil {
-		return "", err
+		return err
 	}
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/pulls/%s/requested_reviewers", c.baseURL, c.owner, c.repo, id)
+	return c.doJSON(http.MethodPost, apiURL, bytes.NewReader(body), nil)
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", fmt.Errorf("github api error (%d): %s", resp.StatusCode, string(data))
+// MergePullRequest merges PR id using GitHub's default merge method.
+func (c *Client) MergePullRequest(id string) error {
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/pulls/%s/merge", c.baseURL, c.owner, c.repo, id)
+	return c.doJSON(http.MethodPut, apiURL, nil, nil)
+}
+
+type githubPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (pr githubPullRequest) toPullRequest() *providers.PullRequest {
+	return &providers.PullRequest{
+		ID:     strconv.Itoa(pr.Number),
+		Title:  pr.Title,
+		URL:    pr.HTMLURL,
+		Source: pr.Head.Ref,
+		Target: pr.Base.Ref,
+		State:  pr.State,
+	}
+}
+
+// doJSON performs an authenticated, retried request and, if out is
+// non-nil, unmarshals the response body into it. Non-2xx responses come
+// back as a *httpx.APIError wrapping httpx.ErrUnauthorized/
+// ErrNotFound/ErrConflict/ErrRateLimited.
+func (c *Client) doJSON(method, apiURL string, body io.Reader, out any) error {
+	req, err := c.retrier.NewRequest(method, apiURL, body)
+	if err != nil {
+		return err
 	}
+	return c.retrier.DoJSON(req, out)
+}
 
+// CheckRun is a single GitHub check-suite entry for a commit.
+type CheckRun struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`     // "queued", "in_progress", "completed"
+	Conclusion string `json:"conclusion"` // "success", "failure", "cancelled", ... (only set once completed)
+	HTMLURL    string `json:"html_url"`
+}
+
+// GetCheckRuns returns every check-run GitHub has recorded for ref (a
+// branch name, tag, or commit SHA).
+func (c *Client) GetCheckRuns(ref string) ([]CheckRun, error) {
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/commits/%s/check-runs", c.baseURL, c.owner, c.repo, url.PathEscape(ref))
 	var result struct {
-		HTMLURL string `json:"html_url"`
+		CheckRuns []CheckRun `json:"check_runs"`
 	}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return "", err
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &result); err != nil {
+		return nil, err
 	}
-	return result.HTMLURL, nil
+	return result.CheckRuns, nil
+}
+
+// CheckPipelineStatus implements providers.PipelineChecker over GitHub's
+// check-runs API. The status is "pending" until every check-run has
+// completed, "failed" if any did not succeed, else "success".
+func (c *Client) CheckPipelineStatus(ref string) (*providers.PipelineStatus, error) {
+	runs, err := c.GetCheckRuns(ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return &providers.PipelineStatus{State: "pending"}, nil
+	}
+
+	status := &providers.PipelineStatus{State: "success", URL: runs[0].HTMLURL}
+	for _, run := range runs {
+		if run.Status != "completed" {
+			return &providers.PipelineStatus{State: "pending", URL: run.HTMLURL}, nil
+		}
+		if run.Conclusion != "success" && run.Conclusion != "neutral" && run.Conclusion != "skipped" {
+			status.State = "failed"
+			status.FailedJobs = append(status.FailedJobs, run.Name)
+		}
+	}
+	return status, nil
+}
+
+func (c *Client) Name() string { return "github" }
+
+func (c *Client) Validate() error {
+	if c.owner == "" || c.repo == "" {
+		return errors.New("GitHub owner/repo not configured")
+	}
+	return nil
+}
+
+// PullRequestURL returns the web UI search for open PRs from branch, since
+// GitHub has no stable "the PR for this branch" endpoint without a lookup.
+func (c *Client) PullRequestURL(branch string) string {
+	webBase := strings.Replace(strings.TrimSuffix(c.baseURL, "/"), "api.", "", 1)
+	return fmt.Sprintf("%s/%s/%s/pulls?q=is%%3Apr+head%%3A%s", webBase, c.owner, c.repo, url.QueryEscape(branch))
 }
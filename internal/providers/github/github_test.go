@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreatePullRequest(t *testing.T) {
@@ -27,6 +28,7 @@ func TestCreatePullRequest(t *testing.T) {
 
 func TestCreatePullRequestError(t *testing.T) {
 	client := NewClient("owner", "repo", "token")
+	client.retrier.BaseDelay = time.Millisecond
 	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
 		return jsonResponse(http.StatusInternalServerError, "fail")
 	})
@@ -36,6 +38,88 @@ func TestCreatePullRequestError(t *testing.T) {
 	}
 }
 
+func TestCheckPipelineStatusSuccess(t *testing.T) {
+	client := NewClient("owner", "repo", "token")
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		if !strings.Contains(req.URL.Path, "/commits/feat/check-runs") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		return jsonResponse(http.StatusOK, `{"check_runs":[{"name":"build","status":"completed","conclusion":"success"}]}`)
+	})
+
+	status, err := client.CheckPipelineStatus("feat")
+	if err != nil {
+		t.Fatalf("CheckPipelineStatus failed: %v", err)
+	}
+	if status.State != "success" {
+		t.Fatalf("state = %s, want success", status.State)
+	}
+}
+
+func TestCheckPipelineStatusFailure(t *testing.T) {
+	client := NewClient("owner", "repo", "token")
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		return jsonResponse(http.StatusOK, `{"check_runs":[{"name":"build","status":"completed","conclusion":"success"},{"name":"lint","status":"completed","conclusion":"failure"}]}`)
+	})
+
+	status, err := client.CheckPipelineStatus("feat")
+	if err != nil {
+		t.Fatalf("CheckPipelineStatus failed: %v", err)
+	}
+	if status.State != "failed" || len(status.FailedJobs) != 1 || status.FailedJobs[0] != "lint" {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestCheckPipelineStatusPending(t *testing.T) {
+	client := NewClient("owner", "repo", "token")
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		return jsonResponse(http.StatusOK, `{"check_runs":[{"name":"build","status":"in_progress"}]}`)
+	})
+
+	status, err := client.CheckPipelineStatus("feat")
+	if err != nil {
+		t.Fatalf("CheckPipelineStatus failed: %v", err)
+	}
+	if status.State != "pending" {
+		t.Fatalf("state = %s, want pending", status.State)
+	}
+}
+
+func TestAddReviewers(t *testing.T) {
+	client := NewClient("owner", "repo", "token")
+	var gotBody string
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		if !strings.Contains(req.URL.Path, "/requested_reviewers") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		body, _ := io.ReadAll(req.Body)
+		gotBody = string(body)
+		return jsonResponse(http.StatusOK, `{}`)
+	})
+
+	if err := client.AddReviewers("5", []string{"octocat"}); err != nil {
+		t.Fatalf("AddReviewers failed: %v", err)
+	}
+	if !strings.Contains(gotBody, "octocat") {
+		t.Fatalf("expected reviewer in request body, got %s", gotBody)
+	}
+}
+
+func TestMergePullRequest(t *testing.T) {
+	client := NewClient("owner", "repo", "token")
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		if req.Method != http.MethodPut || !strings.Contains(req.URL.Path, "/pulls/5/merge") {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		return jsonResponse(http.StatusOK, `{"merged":true}`)
+	})
+
+	if err := client.MergePullRequest("5"); err != nil {
+		t.Fatalf("MergePullRequest failed: %v", err)
+	}
+}
+
 type roundTripFunc func(*http.Request) *http.Response
 
 func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -3,28 +3,88 @@ package gitlab
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/Ilia01/devflow/internal/auth"
+	"github.com/Ilia01/devflow/internal/httpx"
+	"github.com/Ilia01/devflow/internal/providers"
 )
 
+func init() {
+	providers.Register("gitlab", func(cfg providers.Config) providers.Provider {
+		client := NewClientWithAPIVersion(cfg.BaseURL, cfg.Token, cfg.APIVersion)
+		client.projectPath = cfg.ProjectPath
+		return client
+	})
+}
+
 type Client struct {
 	baseURL string
 	token   string
 	http    *http.Client
+	retrier *httpx.Client
+
+	// projectPath and apiVersion are only set when the client is built
+	// through the providers registry; direct NewClient callers that only
+	// need CreateMergeRequest can ignore both.
+	projectPath string
+	apiVersion  string
 }
 
 func NewClient(baseURL, token string) *Client {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	retrier := httpx.NewClient(httpClient)
+	retrier.Auth = func(req *http.Request) error {
+		req.Header.Set("PRIVATE-TOKEN", token)
+		req.Header.Set("Content-Type", "application/json")
+		return nil
+	}
 	return &Client{
 		baseURL: strings.TrimRight(baseURL, "/"),
 		token:   token,
-		http:    &http.Client{Timeout: 30 * time.Second},
+		http:    httpClient,
+		retrier: retrier,
+	}
+}
+
+// NewClientWithAPIVersion is like NewClient but lets older self-hosted
+// GitLab instances pin the v3 API path via git.api_version.
+func NewClientWithAPIVersion(baseURL, token, apiVersion string) *Client {
+	client := NewClient(baseURL, token)
+	client.apiVersion = apiVersion
+	return client
+}
+
+// NewClientWithCredential is like NewClient but takes an auth.Credential
+// from the target+userID credential store instead of a raw token, for
+// callers managing multiple identities via `devflow auth`. GitLab's
+// PRIVATE-TOKEN header only ever carries a bearer-style secret, so only
+// TokenCredential and OAuth2Credential are supported.
+func NewClientWithCredential(baseURL string, cred auth.Credential) (*Client, error) {
+	switch c := cred.(type) {
+	case *auth.TokenCredential:
+		return NewClient(baseURL, c.Token), nil
+	case *auth.OAuth2Credential:
+		return NewClient(baseURL, c.AccessToken), nil
+	default:
+		return nil, fmt.Errorf("gitlab: unsupported credential kind %q", cred.Kind())
 	}
 }
 
+func (c *Client) apiPath() string {
+	if c.apiVersion == "v3" {
+		return "v3"
+	}
+	return "v4"
+}
+
 func (c *Client) CreateMergeRequest(projectPath, sourceBranch, targetBranch, title, description string) (string, error) {
 	projectID, err := c.getProjectID(projectPath)
 	if err != nil {
@@ -43,65 +103,247 @@ func (c *Client) CreateMergeRequest(projectPath, sourceBranch, targetBranch, tit
 		return "", err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v4/projects/%d/merge_requests", c.baseURL, projectID), bytes.NewReader(body))
-	if err != nil {
+	apiURL := fmt.Sprintf("%s/api/%s/projects/%d/merge_requests", c.baseURL, c.apiPath(), projectID)
+	var result struct {
+		WebURL string `json:"web_url"`
+	}
+	if err := c.doJSON(http.MethodPost, apiURL, bytes.NewReader(body), &result); err != nil {
 		return "", err
 	}
-	req.Header.Set("PRIVATE-TOKEN", c.token)
-	req.Header.Set("Content-Type", "application/json")
+	return result.WebURL, nil
+}
 
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return "", err
+func (c *Client) getProjectID(projectPath string) (int64, error) {
+	encoded := url.PathEscape(projectPath)
+	apiURL := fmt.Sprintf("%s/api/%s/projects/%s", c.baseURL, c.apiPath(), encoded)
+	var result struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &result); err != nil {
+		return 0, err
 	}
-	defer resp.Body.Close()
+	return result.ID, nil
+}
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
+func (c *Client) Name() string { return "gitlab" }
+
+func (c *Client) Validate() error {
+	if c.baseURL == "" || c.token == "" {
+		return errors.New("GitLab base URL/token not configured")
 	}
+	return nil
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", fmt.Errorf("gitlab api error (%d): %s", resp.StatusCode, string(data))
+// CreatePullRequest implements providers.Provider by delegating to
+// CreateMergeRequest using the project path the client was built with.
+func (c *Client) CreatePullRequest(sourceBranch, targetBranch, title, description string) (string, error) {
+	if c.projectPath == "" {
+		return "", errors.New("gitlab project path not set")
 	}
+	return c.CreateMergeRequest(c.projectPath, sourceBranch, targetBranch, title, description)
+}
 
-	var result struct {
-		WebURL string `json:"web_url"`
+func (c *Client) PullRequestURL(branch string) string {
+	return fmt.Sprintf("%s/merge_requests?scope=all&state=opened&source_branch=%s", c.baseURL, url.QueryEscape(branch))
+}
+
+// GetPullRequest looks up a single merge request by its IID (passed as a
+// string to satisfy the provider-agnostic interface).
+func (c *Client) GetPullRequest(id string) (*providers.PullRequest, error) {
+	projectID, err := c.getProjectID(c.projectPath)
+	if err != nil {
+		return nil, err
 	}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return "", err
+	var mr gitlabMergeRequest
+	apiURL := fmt.Sprintf("%s/api/%s/projects/%d/merge_requests/%s", c.baseURL, c.apiPath(), projectID, id)
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &mr); err != nil {
+		return nil, err
 	}
-	return result.WebURL, nil
+	return mr.toPullRequest(), nil
 }
 
-func (c *Client) getProjectID(projectPath string) (int64, error) {
-	encoded := url.PathEscape(projectPath)
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v4/projects/%s", c.baseURL, encoded), nil)
+// ListOpenPullRequests returns the project's currently open merge requests.
+func (c *Client) ListOpenPullRequests() ([]providers.PullRequest, error) {
+	projectID, err := c.getProjectID(c.projectPath)
 	if err != nil {
+		return nil, err
+	}
+	var mrs []gitlabMergeRequest
+	apiURL := fmt.Sprintf("%s/api/%s/projects/%d/merge_requests?state=opened", c.baseURL, c.apiPath(), projectID)
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &mrs); err != nil {
+		return nil, err
+	}
+	result := make([]providers.PullRequest, 0, len(mrs))
+	for _, mr := range mrs {
+		result = append(result, *mr.toPullRequest())
+	}
+	return result, nil
+}
+
+// TestConnection checks that the configured token can resolve the
+// configured project.
+func (c *Client) TestConnection() error {
+	_, err := c.getProjectID(c.projectPath)
+	return err
+}
+
+// getUserID resolves a GitLab username to the numeric ID the merge
+// request API expects for reviewer_ids.
+func (c *Client) getUserID(username string) (int64, error) {
+	apiURL := fmt.Sprintf("%s/api/%s/users?username=%s", c.baseURL, c.apiPath(), url.QueryEscape(username))
+	var users []struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &users); err != nil {
 		return 0, err
 	}
-	req.Header.Set("PRIVATE-TOKEN", c.token)
+	if len(users) == 0 {
+		return 0, fmt.Errorf("gitlab: no user found for username %q", username)
+	}
+	return users[0].ID, nil
+}
 
-	resp, err := c.http.Do(req)
+// AddReviewers sets the reviewers on merge request id (passed as a string
+// to satisfy the provider-agnostic interface), resolving each GitLab
+// username to its user ID first.
+func (c *Client) AddReviewers(id string, reviewers []string) error {
+	projectID, err := c.getProjectID(c.projectPath)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	reviewerIDs := make([]int64, 0, len(reviewers))
+	for _, username := range reviewers {
+		userID, err := c.getUserID(username)
+		if err != nil {
+			return err
+		}
+		reviewerIDs = append(reviewerIDs, userID)
+	}
+
+	payload := map[string]any{"reviewer_ids": reviewerIDs}
+	body, err := json.Marshal(payload)
 	if err != nil {
-		return 0, err
+		return err
 	}
+	apiURL := fmt.Sprintf("%s/api/%s/projects/%d/merge_requests/%s", c.baseURL, c.apiPath(), projectID, id)
+	return c.doJSON(http.MethodPut, apiURL, bytes.NewReader(body), nil)
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return 0, fmt.Errorf("gitlab api error (%d): %s", resp.StatusCode, string(data))
+// MergePullRequest merges the merge request identified by id.
+func (c *Client) MergePullRequest(id string) error {
+	projectID, err := c.getProjectID(c.projectPath)
+	if err != nil {
+		return err
 	}
+	apiURL := fmt.Sprintf("%s/api/%s/projects/%d/merge_requests/%s/merge", c.baseURL, c.apiPath(), projectID, id)
+	return c.doJSON(http.MethodPut, apiURL, nil, nil)
+}
 
-	var result struct {
-		ID int64 `json:"id"`
+// Pipeline is GitLab's CI run for a single ref.
+type Pipeline struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+	WebURL string `json:"web_url"`
+}
+
+// PipelineJob is a single job within a pipeline.
+type PipelineJob struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// GetLatestPipelineForRef returns the most recently created pipeline for
+// ref, or nil if the project has none yet.
+func (c *Client) GetLatestPipelineForRef(projectID int64, ref string) (*Pipeline, error) {
+	apiURL := fmt.Sprintf("%s/api/%s/projects/%d/pipelines?ref=%s&order_by=id&sort=desc",
+		c.baseURL, c.apiPath(), projectID, url.QueryEscape(ref))
+	var pipelines []Pipeline
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &pipelines); err != nil {
+		return nil, err
 	}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return 0, err
+	if len(pipelines) == 0 {
+		return nil, nil
 	}
-	return result.ID, nil
+	return &pipelines[0], nil
+}
+
+// GetPipelineJobs returns every job that ran as part of pipelineID.
+func (c *Client) GetPipelineJobs(projectID, pipelineID int64) ([]PipelineJob, error) {
+	apiURL := fmt.Sprintf("%s/api/%s/projects/%d/pipelines/%d/jobs", c.baseURL, c.apiPath(), projectID, pipelineID)
+	var jobs []PipelineJob
+	if err := c.doJSON(http.MethodGet, apiURL, nil, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// CheckPipelineStatus implements providers.PipelineChecker by resolving
+// the configured project's latest pipeline for ref and, if it failed,
+// listing the jobs that didn't pass.
+func (c *Client) CheckPipelineStatus(ref string) (*providers.PipelineStatus, error) {
+	projectID, err := c.getProjectID(c.projectPath)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := c.GetLatestPipelineForRef(projectID, ref)
+	if err != nil {
+		return nil, err
+	}
+	if pipeline == nil {
+		return &providers.PipelineStatus{State: "pending"}, nil
+	}
+
+	status := &providers.PipelineStatus{URL: pipeline.WebURL}
+	switch pipeline.Status {
+	case "success":
+		status.State = "success"
+	case "failed", "canceled":
+		status.State = "failed"
+		jobs, err := c.GetPipelineJobs(projectID, pipeline.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			if job.Status == "failed" {
+				status.FailedJobs = append(status.FailedJobs, job.Name)
+			}
+		}
+	default:
+		status.State = "pending"
+	}
+	return status, nil
+}
+
+type gitlabMergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	WebURL       string `json:"web_url"`
+	State        string `json:"state"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+}
+
+func (mr gitlabMergeRequest) toPullRequest() *providers.PullRequest {
+	return &providers.PullRequest{
+		ID:     strconv.Itoa(mr.IID),
+		Title:  mr.Title,
+		URL:    mr.WebURL,
+		Source: mr.SourceBranch,
+		Target: mr.TargetBranch,
+		State:  mr.State,
+	}
+}
+
+// doJSON performs an authenticated, retried request and, if out is
+// non-nil, unmarshals the response body into it. Non-2xx responses come
+// back as a *httpx.APIError wrapping httpx.ErrUnauthorized/
+// ErrNotFound/ErrConflict/ErrRateLimited, so callers can branch on
+// errors.Is instead of the response body.
+func (c *Client) doJSON(method, apiURL string, body io.Reader, out any) error {
+	req, err := c.retrier.NewRequest(method, apiURL, body)
+	if err != nil {
+		return err
+	}
+	return c.retrier.DoJSON(req, out)
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreateMergeRequest(t *testing.T) {
@@ -37,6 +38,7 @@ func TestCreateMergeRequest(t *testing.T) {
 
 func TestCreateMergeRequestProjectError(t *testing.T) {
 	client := NewClient("https://gitlab.example.com", "token")
+	client.retrier.BaseDelay = time.Millisecond
 	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
 		return jsonResponse(http.StatusInternalServerError, "fail")
 	})
@@ -45,6 +47,104 @@ func TestCreateMergeRequestProjectError(t *testing.T) {
 	}
 }
 
+func TestCheckPipelineStatusSuccess(t *testing.T) {
+	client := NewClient("https://gitlab.example.com", "token")
+	client.projectPath = "owner/repo"
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		switch {
+		case strings.Contains(req.URL.EscapedPath(), "/projects/owner%2Frepo") && !strings.Contains(req.URL.Path, "/pipelines"):
+			return jsonResponse(http.StatusOK, `{"id":123}`)
+		case strings.Contains(req.URL.Path, "/pipelines") && !strings.Contains(req.URL.Path, "/jobs"):
+			return jsonResponse(http.StatusOK, `[{"id":9,"status":"success","web_url":"https://gitlab/pipe/9"}]`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+			return nil
+		}
+	})
+
+	status, err := client.CheckPipelineStatus("feat")
+	if err != nil {
+		t.Fatalf("CheckPipelineStatus failed: %v", err)
+	}
+	if status.State != "success" {
+		t.Fatalf("state = %s, want success", status.State)
+	}
+}
+
+func TestCheckPipelineStatusFailure(t *testing.T) {
+	client := NewClient("https://gitlab.example.com", "token")
+	client.projectPath = "owner/repo"
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		switch {
+		case strings.Contains(req.URL.EscapedPath(), "/projects/owner%2Frepo") && !strings.Contains(req.URL.Path, "/pipelines"):
+			return jsonResponse(http.StatusOK, `{"id":123}`)
+		case strings.Contains(req.URL.Path, "/jobs"):
+			return jsonResponse(http.StatusOK, `[{"name":"build","status":"success"},{"name":"lint","status":"failed"}]`)
+		case strings.Contains(req.URL.Path, "/pipelines"):
+			return jsonResponse(http.StatusOK, `[{"id":9,"status":"failed","web_url":"https://gitlab/pipe/9"}]`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+			return nil
+		}
+	})
+
+	status, err := client.CheckPipelineStatus("feat")
+	if err != nil {
+		t.Fatalf("CheckPipelineStatus failed: %v", err)
+	}
+	if status.State != "failed" || len(status.FailedJobs) != 1 || status.FailedJobs[0] != "lint" {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestAddReviewersResolvesUsernames(t *testing.T) {
+	client := NewClient("https://gitlab.example.com", "token")
+	client.projectPath = "owner/repo"
+	var sentReviewerIDs string
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		switch {
+		case strings.Contains(req.URL.Path, "/users"):
+			return jsonResponse(http.StatusOK, `[{"id":42}]`)
+		case strings.Contains(req.URL.Path, "/merge_requests/1") && req.Method == http.MethodPut:
+			body, _ := io.ReadAll(req.Body)
+			sentReviewerIDs = string(body)
+			return jsonResponse(http.StatusOK, `{}`)
+		case strings.Contains(req.URL.EscapedPath(), "/projects/owner%2Frepo") && !strings.Contains(req.URL.Path, "/merge_requests"):
+			return jsonResponse(http.StatusOK, `{"id":123}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+			return nil
+		}
+	})
+
+	if err := client.AddReviewers("1", []string{"alice"}); err != nil {
+		t.Fatalf("AddReviewers failed: %v", err)
+	}
+	if !strings.Contains(sentReviewerIDs, "42") {
+		t.Fatalf("expected resolved reviewer id in request body, got %s", sentReviewerIDs)
+	}
+}
+
+func TestMergePullRequest(t *testing.T) {
+	client := NewClient("https://gitlab.example.com", "token")
+	client.projectPath = "owner/repo"
+	client.http.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		switch {
+		case strings.Contains(req.URL.Path, "/merge_requests/1/merge"):
+			return jsonResponse(http.StatusOK, `{}`)
+		case strings.Contains(req.URL.EscapedPath(), "/projects/owner%2Frepo"):
+			return jsonResponse(http.StatusOK, `{"id":123}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+			return nil
+		}
+	})
+
+	if err := client.MergePullRequest("1"); err != nil {
+		t.Fatalf("MergePullRequest failed: %v", err)
+	}
+}
+
 type roundTripFunc func(*http.Request) *http.Response
 
 func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -0,0 +1,99 @@
+// Package providers defines the pluggable Git hosting backend used by
+// `devflow open --pr` and `devflow done`. Concrete backends (github, gitlab,
+// bitbucket, gitea) self-register via init() by calling Register.
+package providers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Provider is a Git hosting backend capable of creating and locating pull
+// requests for a repository.
+type Provider interface {
+	Name() string
+	CreatePullRequest(sourceBranch, targetBranch, title, description string) (string, error)
+	GetPullRequest(id string) (*PullRequest, error)
+	ListOpenPullRequests() ([]PullRequest, error)
+	AddReviewers(id string, reviewers []string) error
+	MergePullRequest(id string) error
+	PullRequestURL(branch string) string
+	TestConnection() error
+	Validate() error
+}
+
+// PullRequest is the provider-agnostic shape GetPullRequest and
+// ListOpenPullRequests return, translated from each backend's own
+// pull/merge request representation.
+type PullRequest struct {
+	ID     string
+	Title  string
+	URL    string
+	Source string
+	Target string
+	State  string
+}
+
+// PipelineStatus is the provider-agnostic outcome of CheckPipelineStatus,
+// translated from each backend's own CI representation (GitLab pipelines,
+// GitHub check-runs).
+type PipelineStatus struct {
+	// State is one of "pending", "success", or "failed".
+	State string
+	// URL points at the pipeline/check-suite in the provider's web UI.
+	URL string
+	// FailedJobs names the jobs/checks that did not pass, populated only
+	// when State is "failed".
+	FailedJobs []string
+}
+
+// PipelineChecker is implemented by providers whose API can report CI
+// status for a ref (GitLab pipelines, GitHub check-runs). Not every
+// backend supports this yet (bitbucket, gitea do not), so callers that
+// need it should type-assert a Provider rather than relying on it being
+// part of the base interface.
+type PipelineChecker interface {
+	CheckPipelineStatus(ref string) (*PipelineStatus, error)
+}
+
+// Config carries everything a ProviderFactory might need to build a
+// Provider. Not every backend uses every field (e.g. ProjectPath is
+// GitLab-specific); unused fields are simply ignored.
+type Config struct {
+	BaseURL     string
+	Owner       string
+	Repo        string
+	Token       string
+	APIVersion  string
+	ProjectPath string
+}
+
+type ProviderFactory func(cfg Config) Provider
+
+var factories = map[string]ProviderFactory{}
+
+// Register makes a provider factory available under name. It is meant to be
+// called from a backend package's init().
+func Register(name string, factory ProviderFactory) {
+	factories[strings.ToLower(name)] = factory
+}
+
+// New builds the provider registered under name, or an error if none is.
+func New(name string, cfg Config) (Provider, error) {
+	factory, ok := factories[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("unsupported git provider: %s", name)
+	}
+	return factory(cfg), nil
+}
+
+// Names returns the registered provider names, sorted for stable prompts.
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
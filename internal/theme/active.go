@@ -0,0 +1,28 @@
+package theme
+
+import "sync/atomic"
+
+// active is the process-wide Theme commands render against, set once at
+// startup by app.applyTheme and read on every Render call.
+var active atomic.Pointer[Theme]
+
+func init() {
+	active.Store(Dark())
+}
+
+// SetActive replaces the process-wide Theme.
+func SetActive(t *Theme) {
+	active.Store(t)
+}
+
+// Active returns the process-wide Theme.
+func Active() *Theme {
+	return active.Load()
+}
+
+// Render applies the process-wide Theme's Style for role to text. This is
+// the call site commands use; Theme.Render itself is for tests and
+// anything that needs a specific Theme instance.
+func Render(role Role, text string) string {
+	return Active().Render(role, text)
+}
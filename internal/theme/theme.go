@@ -0,0 +1,211 @@
+// Package theme lets users remap every semantically-colored element devflow
+// prints (success/error/warning lines, branch and commit highlights, diff
+// markers) without touching code -- for colorblind users or unusual
+// terminal palettes. Commands call theme.Render(role, text) instead of
+// utils.Green/Red/Cyan directly; Load overlays a user's theme.toml on top
+// of a built-in Dark or Light base, and --no-theme swaps in Disabled.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/Ilia01/devflow/internal/utils"
+)
+
+// Role is a semantic meaning a themed element carries, independent of any
+// specific color -- the thing a theme file assigns a Style to.
+type Role string
+
+const (
+	RoleSuccess Role = "success"
+	RoleError   Role = "error"
+	RoleWarn    Role = "warn"
+	RoleHeading Role = "heading"
+	RolePath    Role = "path"
+	RoleCommit  Role = "commit"
+	RoleBranch  Role = "branch"
+	RoleDiffAdd Role = "diff_add"
+	RoleDiffDel Role = "diff_del"
+)
+
+// Theme maps each Role to a rendering Style. The zero value (and any
+// *Theme built with Disabled) renders every role as plain text.
+type Theme struct {
+	Name     string
+	styles   map[Role]utils.Style
+	disabled bool
+}
+
+// Render applies t's Style for role to text, or returns text unchanged if
+// t is nil, disabled, or has no Style registered for that role.
+func (t *Theme) Render(role Role, text string) string {
+	if t == nil || t.disabled {
+		return text
+	}
+	style, ok := t.styles[role]
+	if !ok {
+		return text
+	}
+	return style.Sprint(text)
+}
+
+// Disabled is the --no-theme theme: every Render call is a no-op passthrough.
+func Disabled() *Theme {
+	return &Theme{Name: "none", disabled: true}
+}
+
+// Dark is devflow's default built-in theme, tuned for dark terminal
+// backgrounds.
+func Dark() *Theme {
+	return &Theme{Name: "dark", styles: map[Role]utils.Style{
+		RoleSuccess: utils.Named("green").Bold(),
+		RoleError:   utils.Named("red").Bold(),
+		RoleWarn:    utils.Named("yellow"),
+		RoleHeading: utils.Named("cyan").Bold(),
+		RolePath:    utils.Named("cyan"),
+		RoleCommit:  utils.Named("yellow"),
+		RoleBranch:  utils.Named("magenta"),
+		RoleDiffAdd: utils.Named("green"),
+		RoleDiffDel: utils.Named("red"),
+	}}
+}
+
+// Light is devflow's built-in theme tuned for light terminal backgrounds,
+// using darker color variants so text stays legible on a white background.
+func Light() *Theme {
+	return &Theme{Name: "light", styles: map[Role]utils.Style{
+		RoleSuccess: utils.Named("darkgreen").Bold(),
+		RoleError:   utils.Named("darkred").Bold(),
+		RoleWarn:    utils.Named("darkorange"),
+		RoleHeading: utils.Named("darkblue").Bold(),
+		RolePath:    utils.Named("darkcyan"),
+		RoleCommit:  utils.Named("darkgoldenrod"),
+		RoleBranch:  utils.Named("indigo"),
+		RoleDiffAdd: utils.Named("darkgreen"),
+		RoleDiffDel: utils.Named("darkred"),
+	}}
+}
+
+// FileSpec is the `[theme]` table of a devflow theme.toml: each field is a
+// style spec like "bold green" or "#a6e22e on #272822" -- a color (an
+// internal/utils.NamedColor name, or "#rrggbb" hex), optionally preceded
+// by the attributes bold/dim/underline/italic, and optionally followed by
+// "on <color>" for a background. A field left empty keeps the base
+// theme's Style for that role.
+type FileSpec struct {
+	Base    string `toml:"base,omitempty"`
+	Success string `toml:"success,omitempty"`
+	Error   string `toml:"error,omitempty"`
+	Warn    string `toml:"warn,omitempty"`
+	Heading string `toml:"heading,omitempty"`
+	Path    string `toml:"path,omitempty"`
+	Commit  string `toml:"commit,omitempty"`
+	Branch  string `toml:"branch,omitempty"`
+	DiffAdd string `toml:"diff_add,omitempty"`
+	DiffDel string `toml:"diff_del,omitempty"`
+}
+
+func (f FileSpec) roles() map[Role]string {
+	return map[Role]string{
+		RoleSuccess: f.Success,
+		RoleError:   f.Error,
+		RoleWarn:    f.Warn,
+		RoleHeading: f.Heading,
+		RolePath:    f.Path,
+		RoleCommit:  f.Commit,
+		RoleBranch:  f.Branch,
+		RoleDiffAdd: f.DiffAdd,
+		RoleDiffDel: f.DiffDel,
+	}
+}
+
+// Load reads <configDir>/theme.toml and overlays its `[theme]` table onto
+// base, so a file that only sets a few roles leaves the rest at base's
+// Style. A missing file returns base unchanged.
+func Load(configDir string, base *Theme) (*Theme, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, "theme.toml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return base, nil
+		}
+		return nil, fmt.Errorf("read theme file: %w", err)
+	}
+
+	var doc struct {
+		Theme FileSpec `toml:"theme"`
+	}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse theme file: %w", err)
+	}
+
+	if doc.Theme.Base == "light" && base.Name == "dark" {
+		base = Light()
+	}
+
+	merged := &Theme{Name: base.Name, styles: make(map[Role]utils.Style, len(base.styles))}
+	for role, style := range base.styles {
+		merged.styles[role] = style
+	}
+	for role, spec := range doc.Theme.roles() {
+		if spec == "" {
+			continue
+		}
+		style, err := parseSpec(spec)
+		if err != nil {
+			return nil, fmt.Errorf("theme role %q: %w", role, err)
+		}
+		merged.styles[role] = style
+	}
+	return merged, nil
+}
+
+func parseSpec(spec string) (utils.Style, error) {
+	style := utils.Style{}
+	background := false
+	for _, tok := range strings.Fields(spec) {
+		switch strings.ToLower(tok) {
+		case "on":
+			background = true
+			continue
+		case "bold":
+			style = style.Bold()
+			continue
+		case "dim":
+			style = style.Dim()
+			continue
+		case "underline":
+			style = style.Underline()
+			continue
+		case "italic":
+			style = style.Italic()
+			continue
+		}
+
+		color, err := resolveColor(tok)
+		if err != nil {
+			return utils.Style{}, fmt.Errorf("parse spec %q: %w", spec, err)
+		}
+		if background {
+			style = style.Background(color)
+			background = false
+		} else {
+			style = style.Foreground(color)
+		}
+	}
+	return style, nil
+}
+
+func resolveColor(token string) (utils.RGBColor, error) {
+	if strings.HasPrefix(token, "#") {
+		return utils.ParseHex(token)
+	}
+	if c, ok := utils.NamedColor[strings.ToLower(token)]; ok {
+		return c, nil
+	}
+	return utils.RGBColor{}, fmt.Errorf("unknown color %q", token)
+}
@@ -0,0 +1,83 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Ilia01/devflow/internal/utils"
+)
+
+func withTrueColor(t *testing.T) {
+	t.Helper()
+	utils.SetColorMode(utils.ColorAlways)
+	prevCapability := utils.Capability()
+	utils.SetColorCapability(utils.CapabilityTrueColor)
+	t.Cleanup(func() {
+		utils.SetColorMode(utils.ColorAuto)
+		utils.SetColorCapability(prevCapability)
+	})
+}
+
+func TestDisabledThemeRendersPlainText(t *testing.T) {
+	withTrueColor(t)
+
+	if got := Disabled().Render(RoleError, "boom"); got != "boom" {
+		t.Fatalf("got %q, want plain text", got)
+	}
+}
+
+func TestDarkThemeRendersStyledText(t *testing.T) {
+	withTrueColor(t)
+
+	got := Dark().Render(RoleSuccess, "ok")
+	want := utils.Named("green").Bold().Sprint("ok")
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestLoadMissingFileReturnsBaseUnchanged(t *testing.T) {
+	base := Dark()
+	loaded, err := Load(t.TempDir(), base)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded != base {
+		t.Fatalf("expected base returned unchanged for a missing theme file")
+	}
+}
+
+func TestLoadOverlaysOnlyConfiguredRoles(t *testing.T) {
+	withTrueColor(t)
+
+	dir := t.TempDir()
+	contents := "[theme]\nsuccess = \"bold #a6e22e\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "theme.toml"), []byte(contents), 0o600); err != nil {
+		t.Fatalf("write theme.toml: %v", err)
+	}
+
+	loaded, err := Load(dir, Dark())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got, want := loaded.Render(RoleSuccess, "ok"), utils.RGB(0xa6, 0xe2, 0x2e).Bold().Sprint("ok"); got != want {
+		t.Fatalf("RoleSuccess: got %q, want %q", got, want)
+	}
+	if got, want := loaded.Render(RoleError, "bad"), Dark().Render(RoleError, "bad"); got != want {
+		t.Fatalf("RoleError should be unchanged from base: got %q, want %q", got, want)
+	}
+}
+
+func TestLoadRejectsUnknownColor(t *testing.T) {
+	dir := t.TempDir()
+	contents := "[theme]\nwarn = \"not-a-color\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "theme.toml"), []byte(contents), 0o600); err != nil {
+		t.Fatalf("write theme.toml: %v", err)
+	}
+
+	if _, err := Load(dir, Dark()); err == nil {
+		t.Fatal("expected an error for an unknown color name")
+	}
+}
@@ -0,0 +1,289 @@
+// Package tui implements the interactive ticket browser shared by
+// `devflow list` and `devflow search`: a scrollable table over a ticket
+// set with a "/" fuzzy filter and inline actions (Enter to open in the
+// browser, s to start work, t to transition status, y to yank the
+// ticket key), built on Bubble Tea.
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Ilia01/devflow/internal/utils"
+)
+
+// Ticket is the subset of ticket data the table renders and filters on.
+type Ticket struct {
+	Key     string
+	Status  string
+	Summary string
+}
+
+// Deps lets the model invoke devflow actions without internal/tui
+// importing internal/app (which owns the handlers and would create an
+// import cycle) or internal/jira directly.
+type Deps struct {
+	// Open opens ticket in the browser.
+	Open func(ticket Ticket) error
+	// Start invokes the same flow as `devflow start <ticket>`.
+	Start func(ticket Ticket) error
+	// Transitions lists the transition names available for ticketKey.
+	Transitions func(ticketKey string) ([]string, error)
+	// Transition applies transitionName to ticketKey.
+	Transition func(ticketKey, transitionName string) error
+	// Yank copies (or prints) the ticket key for the user to paste.
+	Yank func(ticket Ticket) error
+}
+
+type mode int
+
+const (
+	modeBrowse mode = iota
+	modeFilter
+	modeTransitionPick
+)
+
+// Model is the Bubble Tea model for the ticket browser.
+type Model struct {
+	tickets  []Ticket
+	filtered []int // indices into tickets
+	cursor   int
+
+	mode   mode
+	filter string
+
+	transitions []string
+	pickCursor  int
+
+	deps   Deps
+	status string
+	err    error
+	done   bool
+}
+
+// New builds a Model over tickets. deps may leave any field nil; the
+// corresponding key is a no-op when pressed.
+func New(tickets []Ticket, deps Deps) Model {
+	m := Model{tickets: tickets, deps: deps}
+	m.applyFilter()
+	return m
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m *Model) applyFilter() {
+	m.filtered = m.filtered[:0]
+	for i, t := range m.tickets {
+		if matches(m.filter, t.Key+" "+t.Summary+" "+t.Status) {
+			m.filtered = append(m.filtered, i)
+		}
+	}
+	if m.cursor >= len(m.filtered) {
+		m.cursor = len(m.filtered) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m Model) selected() (Ticket, bool) {
+	if len(m.filtered) == 0 {
+		return Ticket{}, false
+	}
+	return m.tickets[m.filtered[m.cursor]], true
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch m.mode {
+	case modeFilter:
+		return m.updateFilter(keyMsg)
+	case modeTransitionPick:
+		return m.updateTransitionPick(keyMsg)
+	default:
+		return m.updateBrowse(keyMsg)
+	}
+}
+
+func (m Model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.filter = ""
+		m.mode = modeBrowse
+		m.applyFilter()
+	case tea.KeyEnter:
+		m.mode = modeBrowse
+	case tea.KeyBackspace:
+		if len(m.filter) > 0 {
+			m.filter = m.filter[:len(m.filter)-1]
+		}
+		m.applyFilter()
+	default:
+		if msg.Type == tea.KeyRunes {
+			m.filter += string(msg.Runes)
+			m.applyFilter()
+		}
+	}
+	return m, nil
+}
+
+func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.done = true
+		return m, tea.Quit
+	case "/":
+		m.mode = modeFilter
+		m.status = ""
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.filtered)-1 {
+			m.cursor++
+		}
+	case "enter":
+		if t, ok := m.selected(); ok && m.deps.Open != nil {
+			m.err = m.deps.Open(t)
+		}
+	case "s":
+		if t, ok := m.selected(); ok && m.deps.Start != nil {
+			m.err = m.deps.Start(t)
+			m.done = true
+			return m, tea.Quit
+		}
+	case "t":
+		if t, ok := m.selected(); ok && m.deps.Transitions != nil {
+			transitions, err := m.deps.Transitions(t.Key)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.transitions = transitions
+			m.pickCursor = 0
+			m.mode = modeTransitionPick
+		}
+	case "y":
+		if t, ok := m.selected(); ok && m.deps.Yank != nil {
+			if err := m.deps.Yank(t); err != nil {
+				m.err = err
+			} else {
+				m.status = fmt.Sprintf("yanked %s", t.Key)
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m Model) updateTransitionPick(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = modeBrowse
+	case "up", "k":
+		if m.pickCursor > 0 {
+			m.pickCursor--
+		}
+	case "down", "j":
+		if m.pickCursor < len(m.transitions)-1 {
+			m.pickCursor++
+		}
+	case "enter":
+		if t, ok := m.selected(); ok && len(m.transitions) > 0 && m.deps.Transition != nil {
+			name := m.transitions[m.pickCursor]
+			if err := m.deps.Transition(t.Key, name); err != nil {
+				m.err = err
+			} else {
+				m.status = fmt.Sprintf("%s -> %s", t.Key, name)
+			}
+		}
+		m.mode = modeBrowse
+	}
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(utils.Cyan(utils.Bold("Tickets")))
+	b.WriteString("\n\n")
+
+	if m.mode == modeFilter {
+		fmt.Fprintf(&b, "%s%s\n\n", utils.Dim("/"), m.filter)
+	} else if m.filter != "" {
+		fmt.Fprintf(&b, "%s\n\n", utils.Dim("filter: "+m.filter))
+	}
+
+	if len(m.filtered) == 0 {
+		b.WriteString(utils.Dim("  No matching tickets\n"))
+	}
+	for row, idx := range m.filtered {
+		t := m.tickets[idx]
+		marker := "  "
+		if row == m.cursor {
+			marker = utils.Green("> ")
+		}
+		fmt.Fprintf(&b, "%s%s [%s]  %s\n", marker, utils.BrightWhite(t.Key), t.Status, t.Summary)
+	}
+
+	if m.mode == modeTransitionPick {
+		b.WriteString("\n")
+		b.WriteString(utils.Cyan("Transition to:"))
+		b.WriteString("\n")
+		for i, name := range m.transitions {
+			marker := "  "
+			if i == m.pickCursor {
+				marker = utils.Green("> ")
+			}
+			fmt.Fprintf(&b, "%s%s\n", marker, name)
+		}
+		b.WriteString(utils.Dim("\n  enter: apply  esc: cancel\n"))
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", utils.Green(m.status))
+	}
+	if m.err != nil {
+		fmt.Fprintf(&b, "\n%s\n", utils.Red(m.err.Error()))
+	}
+
+	b.WriteString(utils.Dim("\n  /: filter  enter: open  s: start  t: transition  y: yank  q: quit\n"))
+	return b.String()
+}
+
+// matches reports whether every rune of query appears in target, in
+// order, case-insensitively — a plain subsequence fuzzy match. An empty
+// query matches everything.
+func matches(query, target string) bool {
+	if query == "" {
+		return true
+	}
+	q := []rune(strings.ToLower(query))
+	target = strings.ToLower(target)
+
+	qi := 0
+	for _, r := range target {
+		if qi >= len(q) {
+			break
+		}
+		if q[qi] == r {
+			qi++
+		}
+	}
+	return qi == len(q)
+}
+
+// Run starts the Bubble Tea program over tickets and blocks until the
+// user quits.
+func Run(tickets []Ticket, deps Deps) error {
+	m := New(tickets, deps)
+	p := tea.NewProgram(m)
+	_, err := p.Run()
+	return err
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		query, target string
+		want          bool
+	}{
+		{"", "anything", true},
+		{"dev", "DEVFLOW-123", true},
+		{"f12", "DEVFLOW-123", true},
+		{"zz", "DEVFLOW-123", false},
+		{"123dev", "DEVFLOW-123", false},
+	}
+	for _, tc := range tests {
+		if got := matches(tc.query, tc.target); got != tc.want {
+			t.Errorf("matches(%q, %q) = %v, want %v", tc.query, tc.target, got, tc.want)
+		}
+	}
+}
+
+func ticketSet() []Ticket {
+	return []Ticket{
+		{Key: "DEVFLOW-1", Status: "To Do", Summary: "Fix login bug"},
+		{Key: "DEVFLOW-2", Status: "In Progress", Summary: "Add dark mode"},
+		{Key: "DEVFLOW-3", Status: "Done", Summary: "Update docs"},
+	}
+}
+
+func keyMsg(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestFilterNarrowsResults(t *testing.T) {
+	m := New(ticketSet(), Deps{})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if m.mode != modeFilter {
+		t.Fatalf("expected modeFilter, got %v", m.mode)
+	}
+	m, _ = update(m, keyMsg('d'))
+	m, _ = update(m, keyMsg('a'))
+	m, _ = update(m, keyMsg('r'))
+	m, _ = update(m, keyMsg('k'))
+	if len(m.filtered) != 1 || m.tickets[m.filtered[0]].Key != "DEVFLOW-2" {
+		t.Fatalf("expected only DEVFLOW-2 to match, got %+v", m.filtered)
+	}
+}
+
+func TestFilterEscClearsFilter(t *testing.T) {
+	m := New(ticketSet(), Deps{})
+	m, _ = update(m, keyMsg('/'))
+	m, _ = update(m, keyMsg('x'))
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != modeBrowse || m.filter != "" || len(m.filtered) != len(m.tickets) {
+		t.Fatalf("expected filter reset, got mode=%v filter=%q filtered=%d", m.mode, m.filter, len(m.filtered))
+	}
+}
+
+func TestCursorMovement(t *testing.T) {
+	m := New(ticketSet(), Deps{})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if m.cursor != 1 {
+		t.Fatalf("cursor = %d, want 1", m.cursor)
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	if m.cursor != 0 {
+		t.Fatalf("cursor = %d, want 0", m.cursor)
+	}
+}
+
+func TestEnterInvokesOpen(t *testing.T) {
+	var opened Ticket
+	m := New(ticketSet(), Deps{Open: func(t Ticket) error { opened = t; return nil }})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if opened.Key != "DEVFLOW-1" {
+		t.Fatalf("Open not invoked with expected ticket, got %+v", opened)
+	}
+}
+
+func TestYankReportsStatus(t *testing.T) {
+	m := New(ticketSet(), Deps{Yank: func(t Ticket) error { return nil }})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if m.status == "" {
+		t.Fatalf("expected status message after yank")
+	}
+}
+
+func TestYankErrorSurfaces(t *testing.T) {
+	wantErr := errors.New("clipboard unavailable")
+	m := New(ticketSet(), Deps{Yank: func(t Ticket) error { return wantErr }})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if !errors.Is(m.err, wantErr) {
+		t.Fatalf("err = %v, want %v", m.err, wantErr)
+	}
+}
+
+func TestTransitionPickApplies(t *testing.T) {
+	var applied string
+	deps := Deps{
+		Transitions: func(key string) ([]string, error) { return []string{"In Progress", "Done"}, nil },
+		Transition:  func(key, name string) error { applied = name; return nil },
+	}
+	m := New(ticketSet(), deps)
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if m.mode != modeTransitionPick {
+		t.Fatalf("expected modeTransitionPick, got %v", m.mode)
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if applied != "Done" {
+		t.Fatalf("applied transition = %q, want %q", applied, "Done")
+	}
+	if m.mode != modeBrowse {
+		t.Fatalf("expected mode to return to browse after applying")
+	}
+}
+
+// update is a small helper adapting Model.Update's (tea.Model, tea.Cmd)
+// return to the concrete Model type tests want to assert against.
+func update(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	next, cmd := m.Update(msg)
+	return next.(Model), cmd
+}
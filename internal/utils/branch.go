@@ -6,7 +6,14 @@ import (
 	"unicode"
 )
 
-func FormatBranchName(prefix, ticketID, summary string) string {
+// FormatBranchName builds a branch name from prefix/ticketID/summary. If
+// prefix contains a "{component}" token, it is replaced with a slugified
+// form of component; when component is empty, the token (and any trailing
+// separator it leaves dangling, e.g. "feat-{component}" -> "feat") is
+// dropped instead.
+func FormatBranchName(prefix, ticketID, summary, component string) string {
+	prefix = applyComponentToken(prefix, component)
+
 	lowered := strings.ToLower(summary)
 	words := strings.FieldsFunc(lowered, func(r rune) bool {
 		switch r {
@@ -40,6 +47,29 @@ func FormatBranchName(prefix, ticketID, summary string) string {
 	return fmt.Sprintf("%s/%s/%s", prefix, ticketID, strings.Join(cleaned, "_"))
 }
 
+func applyComponentToken(prefix, component string) string {
+	if !strings.Contains(prefix, "{component}") {
+		return prefix
+	}
+	slug := slugifyComponent(component)
+	if slug == "" {
+		return strings.TrimRight(strings.ReplaceAll(prefix, "{component}", ""), "-_/")
+	}
+	return strings.ReplaceAll(prefix, "{component}", slug)
+}
+
+func slugifyComponent(component string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(component) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		} else if builder.Len() > 0 {
+			builder.WriteRune('-')
+		}
+	}
+	return strings.TrimRight(builder.String(), "-")
+}
+
 func ExtractTicketID(branch string) (string, error) {
 	parts := strings.Split(branch, "/")
 	if len(parts) < 2 {
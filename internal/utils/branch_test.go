@@ -4,21 +4,24 @@ import "testing"
 
 func TestFormatBranchName(t *testing.T) {
 	tests := []struct {
-		name     string
-		prefix   string
-		ticketID string
-		summary  string
-		want     string
+		name      string
+		prefix    string
+		ticketID  string
+		summary   string
+		component string
+		want      string
 	}{
-		{"basic", "feat", "WAB-1234", "Add user authentication", "feat/WAB-1234/add_user_authentication"},
-		{"special chars", "fix", "PROJ-999", "Fix bug: login doesn't work!", "fix/PROJ-999/fix_bug_login_doesnt_work"},
-		{"numbers", "feat", "ABC-42", "Update Node.js to v20", "feat/ABC-42/update_node_js_to_v20"},
-		{"empty summary", "test", "TICKET-1", "", "test/TICKET-1"},
+		{"basic", "feat", "WAB-1234", "Add user authentication", "", "feat/WAB-1234/add_user_authentication"},
+		{"special chars", "fix", "PROJ-999", "Fix bug: login doesn't work!", "", "fix/PROJ-999/fix_bug_login_doesnt_work"},
+		{"numbers", "feat", "ABC-42", "Update Node.js to v20", "", "feat/ABC-42/update_node_js_to_v20"},
+		{"empty summary", "test", "TICKET-1", "", "", "test/TICKET-1"},
+		{"component token filled", "feat/{component}", "WAB-1234", "Add user authentication", "API Gateway", "feat/api-gateway/WAB-1234/add_user_authentication"},
+		{"component token empty", "feat-{component}", "WAB-1234", "Add user authentication", "", "feat/WAB-1234/add_user_authentication"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := FormatBranchName(tt.prefix, tt.ticketID, tt.summary)
+			got := FormatBranchName(tt.prefix, tt.ticketID, tt.summary, tt.component)
 			if got != tt.want {
 				t.Fatalf("FormatBranchName() = %s, want %s", got, tt.want)
 			}
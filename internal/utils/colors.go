@@ -1,6 +1,13 @@
 package utils
 
-import "strings"
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
 
 const (
 	colorReset = "\033[0m"
@@ -16,13 +23,94 @@ const (
 	colorBrightWhite = "\033[97m"
 )
 
-func Colorize(text string, codes ...string) string {
-	if len(codes) == 0 {
+// Exported color codes for FprintColored callers printing to a stream
+// other than stdout (Cyan/Green/etc. cover the common stdout case).
+const (
+	ColorRed         = colorRed
+	ColorGreen       = colorGreen
+	ColorYellow      = colorYellow
+	ColorBlue        = colorBlue
+	ColorMagenta     = colorMagenta
+	ColorCyan        = colorCyan
+	ColorBrightWhite = colorBrightWhite
+	ColorBold        = colorBold
+	ColorDim         = colorDim
+)
+
+// ColorMode overrides whether color output is auto-detected per stream.
+type ColorMode int
+
+const (
+	// ColorAuto enables color only on a stream that is a TTY, unless
+	// overridden by NO_COLOR/FORCE_COLOR/CLICOLOR_FORCE. This is the default.
+	ColorAuto ColorMode = iota
+	// ColorAlways emits color regardless of TTY status or env vars.
+	ColorAlways
+	// ColorNever strips color regardless of TTY status or env vars.
+	ColorNever
+)
+
+// colorMode is normally wired to a --color={auto,always,never} global flag
+// via SetColorMode.
+var colorMode = ColorAuto
+
+// SetColorMode overrides auto-detection for every subsequent call to
+// Colorize/FprintColored, normally wired to a --color global flag.
+func SetColorMode(mode ColorMode) {
+	colorMode = mode
+}
+
+// colorEnabledFor decides whether w should receive ANSI escapes: an
+// explicit ColorMode wins outright, then NO_COLOR forces color off,
+// then FORCE_COLOR/CLICOLOR_FORCE force it on, and otherwise it follows
+// w's own TTY status so redirecting one stream doesn't affect the other.
+func colorEnabledFor(w io.Writer) bool {
+	switch colorMode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if v, ok := os.LookupEnv("FORCE_COLOR"); ok && v != "0" && v != "" {
+		return true
+	}
+	if _, ok := os.LookupEnv("CLICOLOR_FORCE"); ok {
+		return true
+	}
+
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+func colorize(w io.Writer, text string, codes ...string) string {
+	if len(codes) == 0 || !colorEnabledFor(w) {
 		return text
 	}
 	return strings.Join(codes, "") + text + colorReset
 }
 
+// Colorize wraps text in codes for stdout, the destination nearly every
+// caller in this module prints to. It disables itself automatically when
+// stdout isn't a TTY (piped into a file, another process, or a CI log).
+func Colorize(text string, codes ...string) string {
+	return colorize(os.Stdout, text, codes...)
+}
+
+// FprintColored writes text to w wrapped in codes, honoring w's own TTY
+// status rather than stdout's. Use this for colored output on a stream
+// other than stdout (e.g. stderr) so redirecting just that stream strips
+// color without affecting the other.
+func FprintColored(w io.Writer, text string, codes ...string) {
+	fmt.Fprint(w, colorize(w, text, codes...))
+}
+
 func Cyan(text string) string        { return Colorize(text, colorCyan) }
 func Green(text string) string       { return Colorize(text, colorGreen) }
 func Yellow(text string) string      { return Colorize(text, colorYellow) }
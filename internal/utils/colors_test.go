@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestColorizeHonorsColorMode(t *testing.T) {
+	defer SetColorMode(ColorAuto)
+
+	SetColorMode(ColorNever)
+	if got := Colorize("hi", colorCyan); got != "hi" {
+		t.Fatalf("ColorNever: got %q, want plain text", got)
+	}
+
+	SetColorMode(ColorAlways)
+	var buf bytes.Buffer
+	FprintColored(&buf, "hi", ColorCyan)
+	if want := colorCyan + "hi" + colorReset; buf.String() != want {
+		t.Fatalf("ColorAlways: got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestColorEnabledForDefaultsOffForNonFileWriter(t *testing.T) {
+	defer SetColorMode(ColorAuto)
+	SetColorMode(ColorAuto)
+
+	var buf bytes.Buffer
+	FprintColored(&buf, "hi", ColorCyan)
+	if buf.String() != "hi" {
+		t.Fatalf("non-TTY writer should stay plain: got %q", buf.String())
+	}
+}
+
+func TestColorEnabledForForceColorOverridesNonTTY(t *testing.T) {
+	defer SetColorMode(ColorAuto)
+	SetColorMode(ColorAuto)
+	t.Setenv("FORCE_COLOR", "1")
+
+	var buf bytes.Buffer
+	FprintColored(&buf, "hi", ColorCyan)
+	if want := colorCyan + "hi" + colorReset; buf.String() != want {
+		t.Fatalf("FORCE_COLOR should force color on: got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestColorEnabledForNoColorOverridesForceColor(t *testing.T) {
+	defer SetColorMode(ColorAuto)
+	SetColorMode(ColorAuto)
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("FORCE_COLOR", "1")
+
+	var buf bytes.Buffer
+	FprintColored(&buf, "hi", ColorCyan)
+	if buf.String() != "hi" {
+		t.Fatalf("NO_COLOR should win over FORCE_COLOR: got %q", buf.String())
+	}
+}
@@ -0,0 +1,251 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// RGBColor is a 24-bit color, the common currency Style, RGB, Hex, and
+// NamedColor all produce. Rendering degrades it to whatever ColorCapability
+// the terminal actually supports.
+type RGBColor struct {
+	R, G, B uint8
+}
+
+// ColorCapability is the color depth a terminal supports, detected once at
+// startup from $COLORTERM/$TERM and overridable via SetColorCapability.
+type ColorCapability int
+
+const (
+	CapabilityNone ColorCapability = iota
+	CapabilityBasic
+	Capability256
+	CapabilityTrueColor
+)
+
+var capability = detectColorCapability()
+
+// detectColorCapability inspects $COLORTERM (set to "truecolor" or "24bit"
+// by most modern terminal emulators) and falls back to $TERM's suffix
+// ("-256color"), matching the heuristic most TUI libraries use.
+func detectColorCapability() ColorCapability {
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return CapabilityTrueColor
+	}
+
+	term := os.Getenv("TERM")
+	switch {
+	case term == "" || term == "dumb":
+		return CapabilityNone
+	case strings.Contains(term, "truecolor"):
+		return CapabilityTrueColor
+	case strings.Contains(term, "256color"):
+		return Capability256
+	default:
+		return CapabilityBasic
+	}
+}
+
+// Capability returns the terminal color depth Style rendering degrades to.
+func Capability() ColorCapability {
+	return capability
+}
+
+// SetColorCapability overrides auto-detection, for a user-configured theme
+// preference or for tests.
+func SetColorCapability(c ColorCapability) {
+	capability = c
+}
+
+// Style composes a foreground/background color and text attributes,
+// rendered by Sprint into whichever escape sequence the detected (or
+// overridden) ColorCapability and ColorMode support.
+type Style struct {
+	fg        *RGBColor
+	bg        *RGBColor
+	bold      bool
+	dim       bool
+	underline bool
+	italic    bool
+}
+
+// RGB builds a Style with foreground color (r, g, b).
+func RGB(r, g, b uint8) Style {
+	return Style{}.Foreground(RGBColor{R: r, G: g, B: b})
+}
+
+// Hex builds a Style with foreground color parsed from a "#rrggbb" (or
+// "rrggbb") string. An unparseable value yields an unstyled Style rather
+// than an error, since callers typically build these from static theme
+// literals where a parse failure is a programming error, not runtime data.
+func Hex(s string) Style {
+	c, err := ParseHex(s)
+	if err != nil {
+		return Style{}
+	}
+	return Style{}.Foreground(c)
+}
+
+// ParseHex parses a "#rrggbb" (or "rrggbb") string into an RGBColor.
+func ParseHex(s string) (RGBColor, error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return RGBColor{}, fmt.Errorf("invalid hex color %q: want #rrggbb", s)
+	}
+	raw, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return RGBColor{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+	}
+	return RGBColor{R: uint8(raw >> 16), G: uint8(raw >> 8), B: uint8(raw)}, nil
+}
+
+func (s Style) Foreground(c RGBColor) Style { s.fg = &c; return s }
+func (s Style) Background(c RGBColor) Style { s.bg = &c; return s }
+func (s Style) Bold() Style                 { s.bold = true; return s }
+func (s Style) Dim() Style                  { s.dim = true; return s }
+func (s Style) Underline() Style            { s.underline = true; return s }
+func (s Style) Italic() Style               { s.italic = true; return s }
+
+// Sprint renders text with the style's escape codes, degraded to the
+// detected ColorCapability, or returned unchanged when color is disabled
+// for stdout (ColorMode/NO_COLOR/non-TTY -- see colorEnabledFor).
+func (s Style) Sprint(text string) string {
+	if !colorEnabledFor(os.Stdout) {
+		return text
+	}
+
+	var codes []string
+	if s.bold {
+		codes = append(codes, colorBold)
+	}
+	if s.dim {
+		codes = append(codes, colorDim)
+	}
+	if s.underline {
+		codes = append(codes, "\033[4m")
+	}
+	if s.italic {
+		codes = append(codes, "\033[3m")
+	}
+	if s.fg != nil {
+		if code := fgEscape(*s.fg); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	if s.bg != nil {
+		if code := bgEscape(*s.bg); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return text
+	}
+	return strings.Join(codes, "") + text + colorReset
+}
+
+func fgEscape(c RGBColor) string {
+	switch Capability() {
+	case CapabilityTrueColor:
+		return fmt.Sprintf("\033[38;2;%d;%d;%dm", c.R, c.G, c.B)
+	case Capability256:
+		return fmt.Sprintf("\033[38;5;%dm", nearest256(c))
+	case CapabilityBasic:
+		return fmt.Sprintf("\033[%dm", 30+nearestBasic(c))
+	default:
+		return ""
+	}
+}
+
+func bgEscape(c RGBColor) string {
+	switch Capability() {
+	case CapabilityTrueColor:
+		return fmt.Sprintf("\033[48;2;%d;%d;%dm", c.R, c.G, c.B)
+	case Capability256:
+		return fmt.Sprintf("\033[48;5;%dm", nearest256(c))
+	case CapabilityBasic:
+		return fmt.Sprintf("\033[%dm", 40+nearestBasic(c))
+	default:
+		return ""
+	}
+}
+
+// cubeLevels are the 6 intensity steps xterm's 6x6x6 color cube (indices
+// 16-231 of the 256-color palette) uses per channel.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// nearest256 finds the closest xterm-256 palette index to c by comparing
+// squared Euclidean distance against the nearest color-cube entry and the
+// nearest grayscale-ramp entry.
+func nearest256(c RGBColor) int {
+	ri, gi, bi := nearestCubeIndex(c.R), nearestCubeIndex(c.G), nearestCubeIndex(c.B)
+	cubeIdx := 16 + 36*ri + 6*gi + bi
+	cubeColor := RGBColor{cubeLevels[ri], cubeLevels[gi], cubeLevels[bi]}
+
+	grayIdx, grayColor := nearestGray(c)
+
+	if squaredDistance(c, cubeColor) <= squaredDistance(c, grayColor) {
+		return cubeIdx
+	}
+	return grayIdx
+}
+
+func nearestCubeIndex(v uint8) int {
+	best, bestDist := 0, int(^uint(0)>>1)
+	for i, level := range cubeLevels {
+		d := int(level) - int(v)
+		if d < 0 {
+			d = -d
+		}
+		if d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+func nearestGray(c RGBColor) (int, RGBColor) {
+	avg := (int(c.R) + int(c.G) + int(c.B)) / 3
+	idx := (avg - 8) / 10
+	if idx < 0 {
+		idx = 0
+	}
+	if idx > 23 {
+		idx = 23
+	}
+	gray := uint8(8 + 10*idx)
+	return 232 + idx, RGBColor{gray, gray, gray}
+}
+
+// basicPalette approximates the 8 standard ANSI colors (codes 30-37/40-47)
+// as pure RGB primaries, for degrading a truecolor request down to the
+// least capable terminals.
+var basicPalette = [8]RGBColor{
+	{0, 0, 0},
+	{255, 0, 0},
+	{0, 255, 0},
+	{255, 255, 0},
+	{0, 0, 255},
+	{255, 0, 255},
+	{0, 255, 255},
+	{255, 255, 255},
+}
+
+func nearestBasic(c RGBColor) int {
+	best, bestDist := 0, squaredDistance(c, basicPalette[0])
+	for i, candidate := range basicPalette[1:] {
+		if d := squaredDistance(c, candidate); d < bestDist {
+			best, bestDist = i+1, d
+		}
+	}
+	return best
+}
+
+func squaredDistance(a, b RGBColor) int {
+	dr := int(a.R) - int(b.R)
+	dg := int(a.G) - int(b.G)
+	db := int(a.B) - int(b.B)
+	return dr*dr + dg*dg + db*db
+}
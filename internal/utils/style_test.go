@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestSprintDegradesByCapability(t *testing.T) {
+	defer SetColorMode(ColorAuto)
+	defer SetColorCapability(detectColorCapability())
+	SetColorMode(ColorAlways)
+
+	style := RGB(255, 0, 0).Bold()
+
+	SetColorCapability(CapabilityTrueColor)
+	if got, want := style.Sprint("x"), "\033[1m\033[38;2;255;0;0mx\033[0m"; got != want {
+		t.Fatalf("truecolor: got %q, want %q", got, want)
+	}
+
+	SetColorCapability(Capability256)
+	if got, want := style.Sprint("x"), "\033[1m\033[38;5;196mx\033[0m"; got != want {
+		t.Fatalf("256-color: got %q, want %q", got, want)
+	}
+
+	SetColorCapability(CapabilityBasic)
+	if got, want := style.Sprint("x"), "\033[1m\033[31mx\033[0m"; got != want {
+		t.Fatalf("basic: got %q, want %q", got, want)
+	}
+
+	SetColorCapability(CapabilityNone)
+	if got, want := style.Sprint("x"), "\033[1mx\033[0m"; got != want {
+		t.Fatalf("none: got %q, want %q", got, want)
+	}
+}
+
+func TestSprintNoopWhenColorDisabled(t *testing.T) {
+	defer SetColorMode(ColorAuto)
+	SetColorMode(ColorNever)
+
+	if got := RGB(255, 0, 0).Sprint("x"); got != "x" {
+		t.Fatalf("ColorNever: got %q, want plain text", got)
+	}
+}
+
+func TestHexParsesLeadingHash(t *testing.T) {
+	c, err := ParseHex("#ff0000")
+	if err != nil {
+		t.Fatalf("ParseHex: %v", err)
+	}
+	if c != (RGBColor{255, 0, 0}) {
+		t.Fatalf("got %+v, want {255 0 0}", c)
+	}
+}
+
+func TestHexInvalidYieldsUnstyledStyle(t *testing.T) {
+	defer SetColorMode(ColorAuto)
+	SetColorMode(ColorAlways)
+	SetColorCapability(CapabilityTrueColor)
+
+	if got := Hex("not-a-color").Sprint("x"); got != "x" {
+		t.Fatalf("invalid hex: got %q, want plain text", got)
+	}
+}
+
+func TestNearest256PrefersGrayscaleForNeutralColors(t *testing.T) {
+	if got := nearest256(RGBColor{128, 128, 128}); got < 232 {
+		t.Fatalf("expected a grayscale-ramp index (>=232), got %d", got)
+	}
+}
+
+func TestNamedColorLookup(t *testing.T) {
+	defer SetColorMode(ColorAuto)
+	defer SetColorCapability(detectColorCapability())
+	SetColorMode(ColorAlways)
+	SetColorCapability(CapabilityTrueColor)
+
+	got := Named("crimson").Sprint("x")
+	want := RGB(220, 20, 60).Sprint("x")
+	if got != want {
+		t.Fatalf("Named(\"crimson\"): got %q, want %q", got, want)
+	}
+
+	if got := Named("not-a-real-color").Sprint("x"); got != "x" {
+		t.Fatalf("unknown name: got %q, want plain text", got)
+	}
+}